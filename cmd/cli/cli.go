@@ -1,5 +1,7 @@
 package main
 
+import _ "time/tzdata" // so -zone works on systems without a zoneinfo database
+
 func main() {
 	initFlags()
 
@@ -29,14 +29,16 @@ func runClock() {
 		select {
 		case <-done:
 			return
-		case t := <-ticker.C:
-			tStr := formatTime(t)
+		case <-ticker.C:
+			now := ops.clock.Now()
+
+			tStr := formatTime(now.Time())
 			if tStr != currTime {
 				currTime = tStr
 				updated = true
 			}
 
-			bt := binarytime.DateFromTime(t)
+			bt := now.In(ops.zone)
 			btStr := formatBTime(bt)
 			if btStr != currBTime {
 				currBTime = btStr
@@ -54,6 +56,10 @@ func runClock() {
 }
 
 func formatTime(t time.Time) string {
+	if loc := ops.zone.Location(); loc != nil {
+		t = t.In(loc)
+	}
+
 	switch ops.format {
 	case "d":
 		return t.Format("2006-01-02")
@@ -67,6 +73,10 @@ func formatTime(t time.Time) string {
 }
 
 func formatBTime(bt binarytime.Date) string {
+	if ops.layout != "" {
+		return bt.Format(ops.layout)
+	}
+
 	switch ops.format {
 	case "d":
 		return bt.DateGlyphs()
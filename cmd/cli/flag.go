@@ -1,15 +1,25 @@
 package main
 
-import "flag"
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/seannyphoenix/binarytime/pkg/binarytime"
+)
 
 type options struct {
 	timeout int
 	format  string
+	layout  string
+	zone    binarytime.Zone
+	clock   binarytime.Clock
 }
 
 var ops = options{
 	timeout: 0,    // Default timeout
 	format:  "dt", // Default format DateTime
+	clock:   binarytime.SystemClock,
 }
 
 func initFlags() {
@@ -31,8 +41,41 @@ func initFlags() {
 	flag.StringVar(&ops.format, "format", "dt", "Output format (default: dt)")
 	flag.StringVar(&ops.format, "f", "dt", "Output format (shorthand, default: dt)")
 
+	flag.StringVar(&ops.layout, "layout", "", "Binary time layout, such as \"%4D.%1x:%1x:%1x\" (overrides format)")
+	flag.StringVar(&ops.layout, "l", "", "Binary time layout (shorthand)")
+
+	var zone string
+	flag.StringVar(&zone, "zone", "", "Time zone for local binary time, such as \"Local\" or \"Europe/Paris\" (default: UTC)")
+	flag.StringVar(&zone, "z", "", "Time zone for local binary time (shorthand)")
+
+	var speed float64
+	flag.Float64Var(&speed, "speed", 1, "Clock speed, such as 60 to fast-forward an hour a minute")
+
 	flag.Parse()
 
+	if speed != 1 {
+		clock, err := binarytime.NewScaledClock(binarytime.SystemClock, binarytime.Now(), speed)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		ops.clock = clock
+	}
+
+	if err := binarytime.ValidateLayout(ops.layout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	if zone != "" {
+		z, err := binarytime.LoadZone(zone)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		ops.zone = z
+	}
+
 	if i {
 		ops.timeout = 0
 	} else if q {
@@ -23,7 +23,7 @@ import (
 
 func main() {
 	go func() {
-		err := run()
+		err := run(binarytime.SystemClock)
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -32,7 +32,8 @@ func main() {
 	app.Main()
 }
 
-func run() error {
+// run draws the time told by clock until the window is closed.
+func run(clock binarytime.Clock) error {
 	var state btapp.State
 
 	if len(os.Args) > 1 {
@@ -50,7 +51,8 @@ func run() error {
 	var frameCount int
 
 	c := btclock.Clock{
-		Framerate: 25,
+		Granularity: binarytime.GranularityNanobit,
+		Framerate:   25,
 	}
 
 	var ops op.Ops
@@ -65,7 +67,7 @@ func run() error {
 			gtx := app.NewContext(&ops, e)
 			paint.Fill(gtx.Ops, color.NRGBA{R: 0, G: 0, B: 0, A: 0xff})
 
-			t = binarytime.DateFromTime(e.Now)
+			t = clock.Now()
 
 			// if !state.Sized {
 			// 	// Size the window to fit the current clock string plus some padding.
@@ -10,31 +10,52 @@ import (
 	"github.com/seannyphoenix/binarytime/pkg/binarytime"
 )
 
+// clock is the source of the current time, replaceable in tests.
+var clock binarytime.Clock = binarytime.SystemClock
+
+// maxLayoutLen bounds the layout query parameter, which with each
+// directive's count capped keeps the response small.
+const maxLayoutLen = 64
+
 type Response struct {
-	BinaryTime string `json:"binaryTime"`
+	BinaryTime json.RawMessage `json:"binaryTime"`
 }
 
 func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
 	// Get current binarytime
-	now := binarytime.Now()
+	now := clock.Now()
 
 	// Get format from query parameters, default to "hex"
-	format := request.QueryStringParameters["format"]
-	if format != "base64" {
-		format = "hex"
+	format := binarytime.JSONHex
+	if request.QueryStringParameters["format"] == "base64" {
+		format = binarytime.JSONBase64
+	}
+
+	// Reject layouts that are too long or that Format can't render
+	layout := request.QueryStringParameters["layout"]
+	if len(layout) > maxLayoutLen || binarytime.ValidateLayout(layout) != nil {
+		return events.APIGatewayProxyResponse{
+			StatusCode: http.StatusBadRequest,
+			Body:       `{"error":"Invalid layout"}`,
+		}, nil
 	}
 
-	// Format the binarytime according to the requested format
-	var timeStr string
-	if format == "base64" {
-		timeStr = now.Fixed128().Base64()
-	} else {
-		timeStr = now.Fixed128().String()
+	// Format the binarytime according to the requested format,
+	// or as a string in the requested layout if there is one
+	timeJSON, err := now.MarshalJSONFormat(format)
+	if layout != "" {
+		timeJSON, err = json.Marshal(now.Format(layout))
+	}
+	if err != nil {
+		return events.APIGatewayProxyResponse{
+			StatusCode: http.StatusInternalServerError,
+			Body:       `{"error":"Failed to generate response"}`,
+		}, nil
 	}
 
 	// Create response body
 	response := Response{
-		BinaryTime: timeStr,
+		BinaryTime: timeJSON,
 	}
 
 	// Convert response to JSON
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/seannyphoenix/binarytime/pkg/binarytime"
+	"github.com/seannyphoenix/binarytime/pkg/fixed128"
+)
+
+func TestHandler(t *testing.T) {
+	defer func(c binarytime.Clock) { clock = c }(clock)
+
+	now, _ := binarytime.DateFromFixed128(fixed128.FromParts(0x40000004e2c, 0x9f3b7a5c1d2e4f68, false))
+	clock = binarytime.NewManualClock(now)
+
+	tt := []struct {
+		query  map[string]string
+		status int
+		body   string
+	}{
+		{nil, http.StatusOK, `{"binaryTime":"0000040000004e2c.9f3b7a5c1d2e4f68"}`},
+		{map[string]string{"format": "base64"}, http.StatusOK, `{"binaryTime":"AAAEAAAATiyfO3pcHS5PaA=="}`},
+		{map[string]string{"layout": binarytime.LayoutClock}, http.StatusOK, `{"binaryTime":"4e2c.9:f:3"}`},
+		{map[string]string{"layout": "%999999999x"}, http.StatusBadRequest, `{"error":"Invalid layout"}`},
+		{map[string]string{"layout": "%y"}, http.StatusBadRequest, `{"error":"Invalid layout"}`},
+	}
+
+	for _, tc := range tt {
+		resp, err := handler(context.Background(), events.APIGatewayProxyRequest{QueryStringParameters: tc.query})
+		if err != nil {
+			t.Fatalf("handler(%v) error = %v", tc.query, err)
+		}
+		if resp.StatusCode != tc.status || resp.Body != tc.body {
+			t.Errorf("handler(%v) = %d %s, want %d %s", tc.query, resp.StatusCode, resp.Body, tc.status, tc.body)
+		}
+	}
+}
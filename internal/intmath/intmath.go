@@ -0,0 +1,12 @@
+// Package intmath holds integer helpers shared by the binarytime packages.
+package intmath
+
+// FloorDiv returns a/b rounded towards negative infinity,
+// where Go's / rounds towards zero.
+func FloorDiv(a, b int64) int64 {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
@@ -0,0 +1,22 @@
+package intmath
+
+import "testing"
+
+func TestFloorDiv(t *testing.T) {
+	tt := []struct {
+		a, b, want int64
+	}{
+		{7, 2, 3},
+		{-7, 2, -4},
+		{7, -2, -4},
+		{-7, -2, 3},
+		{-8, 2, -4},
+		{0, 5, 0},
+	}
+
+	for _, tc := range tt {
+		if got := FloorDiv(tc.a, tc.b); got != tc.want {
+			t.Errorf("FloorDiv(%d, %d) = %d, want %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
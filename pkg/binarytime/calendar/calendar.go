@@ -0,0 +1,128 @@
+// Package calendar groups the whole days of binary time into years of 256
+// days, months of 16 days and weeks of 4 days, so that each hex digit of
+// the day count names one field. The 16 days of a month are drawn as a 4x4
+// grid in Z-order, following html/calendar.md, which puts each week in a
+// 2x2 quadrant.
+package calendar
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+
+	"github.com/seannyphoenix/binarytime/pkg/binarytime"
+	"github.com/seannyphoenix/binarytime/pkg/fixed128"
+	"github.com/seannyphoenix/binarytime/pkg/zordercurve"
+)
+
+const (
+	DaysPerWeek   = 4
+	WeeksPerMonth = 4
+	MonthsPerYear = 16
+	DaysPerMonth  = DaysPerWeek * WeeksPerMonth
+	DaysPerYear   = DaysPerMonth * MonthsPerYear
+)
+
+var ErrOutOfRange = errors.New("calendar date out of range")
+
+// Day is a whole day of binary time, counted from the zero date.
+type Day uint64
+
+// FromDate returns the Day containing the Date.
+func FromDate(d binarytime.Date) Day {
+	hi, _, _ := d.Fixed128().Parts()
+	return Day(hi)
+}
+
+// FromFields returns the Day with the given year, month of the year,
+// week of the month and day of the week, all counted from zero.
+func FromFields(year uint64, month, week, weekday int) (Day, error) {
+	if year >= 1<<56 || month < 0 || month >= MonthsPerYear ||
+		week < 0 || week >= WeeksPerMonth || weekday < 0 || weekday >= DaysPerWeek {
+		return 0, fmt.Errorf("%w: %x-%x-%x-%x", ErrOutOfRange, year, month, week, weekday)
+	}
+	return Day(year<<8 | uint64(month)<<4 | uint64(week)<<2 | uint64(weekday)), nil
+}
+
+// Date returns the start of the Day.
+func (d Day) Date() binarytime.Date {
+	date, _ := binarytime.DateFromFixed128(fixed128.FromParts(uint64(d), 0, false))
+	return date
+}
+
+// Year returns the count of whole 256-day years before the Day.
+func (d Day) Year() uint64 {
+	return uint64(d) >> 8
+}
+
+// Month returns the month of the year, from 0 to 15.
+func (d Day) Month() int {
+	return int(d>>4) & 0xf
+}
+
+// Week returns the week of the month, from 0 to 3.
+func (d Day) Week() int {
+	return int(d>>2) & 0x3
+}
+
+// Weekday returns the day of the week, from 0 to 3.
+func (d Day) Weekday() int {
+	return int(d) & 0x3
+}
+
+// DayOfMonth returns the day of the month, from 0 to 15.
+func (d Day) DayOfMonth() int {
+	return int(d) & 0xf
+}
+
+// DayOfYear returns the day of the year, from 0 to 255.
+func (d Day) DayOfYear() int {
+	return int(d) & 0xff
+}
+
+// GridPosition returns the column and row of the Day in its month's grid.
+func (d Day) GridPosition() (x, y int) {
+	cx, cy := zordercurve.TwoDimension.GetCoords(uint64(d.DayOfMonth()))
+	return int(cx), int(cy)
+}
+
+// String returns the year, month and day of the month in hex,
+// such as "40000004e-2-c".
+func (d Day) String() string {
+	return fmt.Sprintf("%x-%x-%x", d.Year(), d.Month(), d.DayOfMonth())
+}
+
+// Start returns the first Day of the period of length g containing the Day.
+// Granularities finer than a day leave the Day unchanged.
+func (d Day) Start(g binarytime.Granularity) Day {
+	return d &^ Day(periodLength(g)-1)
+}
+
+// Days iterates over every Day of the period of length g containing the Day.
+func (d Day) Days(g binarytime.Granularity) iter.Seq[Day] {
+	start := d.Start(g)
+	return Periods(start, start+Day(periodLength(g)-1), binarytime.GranularityDay)
+}
+
+// Periods iterates over the first Day of each period of length g that
+// overlaps the inclusive range from start to end.
+func Periods(start, end Day, g binarytime.Granularity) iter.Seq[Day] {
+	return func(yield func(Day) bool) {
+		if end < start {
+			return
+		}
+		step := Day(periodLength(g))
+		for d := start.Start(g); ; d += step {
+			if !yield(d) || end-d < step {
+				return
+			}
+		}
+	}
+}
+
+// periodLength returns the number of days in a period of length g,
+// which is at least 1 and at most 2^60.
+func periodLength(g binarytime.Granularity) uint64 {
+	g = min(max(g, binarytime.GranularityEon), binarytime.GranularityDay)
+	return 1 << -g
+}
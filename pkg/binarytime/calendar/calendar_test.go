@@ -0,0 +1,187 @@
+package calendar
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/seannyphoenix/binarytime/pkg/binarytime"
+)
+
+func TestFields(t *testing.T) {
+	// 20240 days after 1970, 0x4f10
+	d := FromDate(binarytime.DateFromTime(time.Date(2025, time.June, 1, 12, 0, 0, 0, time.UTC)))
+
+	if d.Year() != 0x40000004f || d.Month() != 0x1 || d.Week() != 0 || d.Weekday() != 0 ||
+		d.DayOfMonth() != 0 || d.DayOfYear() != 0x10 {
+		t.Errorf("fields of %v = %x %x %x %x", d, d.Year(), d.Month(), d.Week(), d.Weekday())
+	}
+	if got := d.String(); got != "40000004f-1-0" {
+		t.Errorf("String() = %q", got)
+	}
+
+	same, err := FromFields(d.Year(), d.Month(), d.Week(), d.Weekday())
+	if err != nil || same != d {
+		t.Errorf("FromFields() = %v, %v, want %v", same, err, d)
+	}
+	if _, err := FromFields(0, 16, 0, 0); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("FromFields() with month 16 error = %v, want ErrOutOfRange", err)
+	}
+
+	if got := FromDate(d.Date()); got != d {
+		t.Errorf("FromDate(Date()) = %v, want %v", got, d)
+	}
+}
+
+func TestGridPosition(t *testing.T) {
+	// Rows of html/calendar.md
+	want := [4][4]int{
+		{0x0, 0x1, 0x4, 0x5},
+		{0x2, 0x3, 0x6, 0x7},
+		{0x8, 0x9, 0xc, 0xd},
+		{0xa, 0xb, 0xe, 0xf},
+	}
+	for y, row := range want {
+		for x, dom := range row {
+			if gx, gy := Day(0x1230 + dom).GridPosition(); gx != x || gy != y {
+				t.Errorf("GridPosition() of day %x = %d, %d, want %d, %d", dom, gx, gy, x, y)
+			}
+		}
+	}
+}
+
+func TestGrid(t *testing.T) {
+	want := "" +
+		"+--+--+--+--+\n" +
+		"|00|01|04|05|\n" +
+		"+--+--+--+--+\n" +
+		"|02|03|06|07|\n" +
+		"+--+--+--+--+\n" +
+		"|08|09|0c|0d|\n" +
+		"+--+--+--+--+\n" +
+		"|0a|0b|0e|0f|\n" +
+		"+--+--+--+--+\n"
+
+	var cells [16]string
+	for i := range cells {
+		cells[i] = string("0123456789abcdef"[i/16]) + string("0123456789abcdef"[i%16])
+	}
+	if got := Grid(cells); got != want {
+		t.Errorf("Grid() =\n%s\nwant\n%s", got, want)
+	}
+
+	month := "" +
+		"+---+---+---+---+\n" +
+		"|00 |01 |04 |05 |\n" +
+		"+---+---+---+---+\n" +
+		"|02 |03 |06 |07 |\n" +
+		"+---+---+---+---+\n" +
+		"|08 |09 |0c*|0d |\n" +
+		"+---+---+---+---+\n" +
+		"|0a |0b |0e |0f |\n" +
+		"+---+---+---+---+\n"
+	if got := Day(0x12c).MonthGrid(); got != month {
+		t.Errorf("MonthGrid() =\n%s\nwant\n%s", got, month)
+	}
+	if got := Day(0xc5).YearGrid(); got != month {
+		t.Errorf("YearGrid() =\n%s\nwant\n%s", got, month)
+	}
+}
+
+func TestPeriods(t *testing.T) {
+	d := Day(0x1236)
+
+	tt := []struct {
+		g     binarytime.Granularity
+		start Day
+		days  int
+	}{
+		{binarytime.GranularityHexYear, 0x1200, 256},
+		{binarytime.GranularityHexMonth, 0x1230, 16},
+		{binarytime.GranularityHexWeek, 0x1234, 4},
+		{binarytime.GranularityDay, 0x1236, 1},
+		{binarytime.GranularityHexHour, 0x1236, 1},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.g.String(), func(t *testing.T) {
+			if got := d.Start(tc.g); got != tc.start {
+				t.Errorf("Start() = %v, want %v", got, tc.start)
+			}
+			days := slices.Collect(d.Days(tc.g))
+			if len(days) != tc.days || days[0] != tc.start || days[len(days)-1] != tc.start+Day(tc.days-1) {
+				t.Errorf("Days() = %d days from %v, want %d from %v", len(days), days[0], tc.days, tc.start)
+			}
+		})
+	}
+
+	weeks := slices.Collect(Periods(0x1236, 0x1241, binarytime.GranularityHexWeek))
+	if want := []Day{0x1234, 0x1238, 0x123c, 0x1240}; !slices.Equal(weeks, want) {
+		t.Errorf("Periods() = %v, want %v", weeks, want)
+	}
+	if got := slices.Collect(Periods(2, 1, binarytime.GranularityDay)); len(got) != 0 {
+		t.Errorf("Periods() of an empty range = %v", got)
+	}
+	last := slices.Collect(Periods(^Day(0)-1, ^Day(0), binarytime.GranularityDay))
+	if len(last) != 2 {
+		t.Errorf("Periods() at the end of time = %v", last)
+	}
+}
+
+func TestGregorian(t *testing.T) {
+	tt := []struct {
+		g   Gregorian
+		day Day
+	}{
+		{Gregorian{1970, time.January, 1}, unixDay},
+		{Gregorian{1969, time.December, 31}, unixDay - 1},
+		{Gregorian{2000, time.February, 29}, unixDay + 11016},
+		{Gregorian{2025, time.June, 1}, 0x40000004f10},
+		{Gregorian{-1, time.March, 1}, unixDay - 719468 - 366},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.g.String(), func(t *testing.T) {
+			day, err := FromGregorian(tc.g)
+			if err != nil || day != tc.day {
+				t.Fatalf("FromGregorian() = %x, %v, want %x", uint64(day), err, uint64(tc.day))
+			}
+			g, err := day.Gregorian()
+			if err != nil || g != tc.g {
+				t.Errorf("Gregorian() = %v, %v, want %v", g, err, tc.g)
+			}
+		})
+	}
+
+	for _, g := range []Gregorian{{2025, time.February, 29}, {2025, 13, 1}, {2025, time.April, 31}, {-20_000_000_000, time.January, 1}} {
+		if _, err := FromGregorian(g); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("FromGregorian(%v) error = %v, want ErrOutOfRange", g, err)
+		}
+	}
+	if _, err := (^Day(0)).Gregorian(); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("Gregorian() of the last day error = %v, want ErrOutOfRange", err)
+	}
+
+	local := time.Date(2025, time.June, 1, 23, 0, 0, 0, time.FixedZone("UTC-5", -5*3600))
+	if got := FromTime(local); got != 0x40000004f10 {
+		t.Errorf("FromTime() = %v, want the local calendar date", got)
+	}
+}
+
+func FuzzGregorianRoundTrip(f *testing.F) {
+	f.Add(uint64(unixDay))
+	f.Add(uint64(0))
+	f.Add(uint64(1 << 62))
+
+	f.Fuzz(func(t *testing.T, n uint64) {
+		d := Day(n)
+		g, err := d.Gregorian()
+		if err != nil {
+			return
+		}
+		if got, err := FromGregorian(g); err == nil && got != d {
+			t.Fatalf("FromGregorian(%v) = %x, want %x", g, uint64(got), n)
+		}
+	})
+}
@@ -0,0 +1,100 @@
+package calendar
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/seannyphoenix/binarytime/internal/intmath"
+)
+
+// unixDay is the Day of 1970-01-01, the Unix epoch.
+const unixDay = Day(1 << 42)
+
+// Gregorian is a date in the proleptic Gregorian calendar.
+// Binary days start at midnight UTC, so each one is a single Gregorian date.
+type Gregorian struct {
+	Year  int64
+	Month time.Month
+	Day   int
+}
+
+func (g Gregorian) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", g.Year, int(g.Month), g.Day)
+}
+
+// FromTime returns the Day of the calendar date of t in t's location.
+func FromTime(t time.Time) Day {
+	year, month, day := t.Date()
+	d, _ := FromGregorian(Gregorian{int64(year), month, day})
+	return d
+}
+
+// FromGregorian returns the Day of the Gregorian date. It returns an
+// ErrOutOfRange error for invalid dates and for those before the zero date.
+func FromGregorian(g Gregorian) (Day, error) {
+	if g.Month < time.January || g.Month > time.December || g.Day < 1 || g.Day > daysIn(g.Year, g.Month) ||
+		g.Year < -(1<<50) || g.Year > 1<<50 {
+		return 0, fmt.Errorf("%w: %v", ErrOutOfRange, g)
+	}
+
+	days := daysFromCivil(g.Year, int64(g.Month), int64(g.Day))
+	if days < -int64(unixDay) {
+		return 0, fmt.Errorf("%w: %v is before the zero date", ErrOutOfRange, g)
+	}
+	return unixDay + Day(days), nil
+}
+
+// Gregorian returns the Gregorian date of the Day. Days more than 2^62,
+// about 1.2e16 years, after 1970 are out of range.
+func (d Day) Gregorian() (Gregorian, error) {
+	if d > unixDay && d-unixDay > 1<<62 {
+		return Gregorian{}, fmt.Errorf("%w: day %x", ErrOutOfRange, uint64(d))
+	}
+	year, month, day := civilFromDays(int64(d - unixDay))
+	return Gregorian{year, time.Month(month), int(day)}, nil
+}
+
+func daysIn(year int64, month time.Month) int {
+	switch month {
+	case time.February:
+		if year%4 == 0 && (year%100 != 0 || year%400 == 0) {
+			return 29
+		}
+		return 28
+	case time.April, time.June, time.September, time.November:
+		return 30
+	}
+	return 31
+}
+
+// daysFromCivil and civilFromDays convert between Gregorian dates and days
+// since 1970-01-01 using Howard Hinnant's algorithms, in 400-year eras
+// starting on March 1st.
+
+func daysFromCivil(y, m, d int64) int64 {
+	if m <= 2 {
+		y--
+	}
+	era := intmath.FloorDiv(y, 400)
+	yoe := y - era*400
+	mp := (m + 9) % 12
+	doy := (153*mp+2)/5 + d - 1
+	doe := yoe*365 + yoe/4 - yoe/100 + doy
+	return era*146097 + doe - 719468
+}
+
+func civilFromDays(z int64) (y, m, d int64) {
+	z += 719468
+	era := intmath.FloorDiv(z, 146097)
+	doe := z - era*146097
+	yoe := (doe - doe/1460 + doe/36524 - doe/146096) / 365
+	doy := doe - (365*yoe + yoe/4 - yoe/100)
+	mp := (5*doy + 2) / 153
+	d = doy - (153*mp+2)/5 + 1
+	m = (mp+2)%12 + 1
+	y = yoe + era*400
+	if m <= 2 {
+		y++
+	}
+	return y, m, d
+}
@@ -0,0 +1,51 @@
+package calendar
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/seannyphoenix/binarytime/pkg/zordercurve"
+)
+
+// Grid draws 16 cells as the 4x4 Z-ordered grid of html/calendar.md,
+// where cell i sits at the column and row given by the Z-order curve.
+// Labels are left-aligned and padded to the width of the longest.
+func Grid(cells [16]string) string {
+	width := 0
+	for _, c := range cells {
+		width = max(width, len(c))
+	}
+	rule := strings.Repeat("+"+strings.Repeat("-", width), 4) + "+\n"
+
+	var b strings.Builder
+	b.WriteString(rule)
+	for y := range uint64(4) {
+		for x := range uint64(4) {
+			fmt.Fprintf(&b, "|%-*s", width, cells[zordercurve.TwoDimension.GetValue(x, y)])
+		}
+		b.WriteString("|\n")
+		b.WriteString(rule)
+	}
+	return b.String()
+}
+
+// MonthGrid draws the month containing the Day, labelling each day with
+// its day of the month in hex and marking the Day itself with '*'.
+func (d Day) MonthGrid() string {
+	return markedGrid(d.DayOfMonth())
+}
+
+// YearGrid draws the 16 months of the year containing the Day in the same
+// layout, labelling each with its month in hex and marking the Day's month.
+func (d Day) YearGrid() string {
+	return markedGrid(d.Month())
+}
+
+func markedGrid(mark int) string {
+	var cells [16]string
+	for i := range cells {
+		cells[i] = fmt.Sprintf("%02x", i)
+	}
+	cells[mark] += "*"
+	return Grid(cells)
+}
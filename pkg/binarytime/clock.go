@@ -0,0 +1,94 @@
+package binarytime
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/seannyphoenix/binarytime/pkg/fixed128"
+)
+
+// Clock tells the current binary time. Code that takes a Clock rather than
+// calling Now can be tested with a ManualClock.
+type Clock interface {
+	Now() Date
+}
+
+// SystemClock is the Clock that Now reads, the system's wall clock
+// unless replaced.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() Date {
+	return DateFromTime(time.Now())
+}
+
+// ManualClock only moves when told to. It is safe for concurrent use.
+type ManualClock struct {
+	mu  sync.Mutex
+	now Date
+}
+
+// NewManualClock returns a ManualClock stopped at start.
+func NewManualClock(start Date) *ManualClock {
+	return &ManualClock{now: start}
+}
+
+func (c *ManualClock) Now() Date {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance moves the clock on by d, or back if d is negative,
+// and returns the new time.
+func (c *ManualClock) Advance(d Duration) Date {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	return c.now
+}
+
+// SetTo moves the clock to the given Date.
+func (c *ManualClock) SetTo(d Date) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = d
+}
+
+// OffsetClock runs a fixed Duration ahead of another Clock,
+// or behind it if the Offset is negative.
+type OffsetClock struct {
+	Clock  Clock
+	Offset Duration
+}
+
+func (c OffsetClock) Now() Date {
+	return c.Clock.Now().Add(c.Offset)
+}
+
+// ScaledClock runs faster or slower than another Clock, starting from a
+// chosen Date. It is meant for demos and for fast-forwarding through days.
+type ScaledClock struct {
+	clock  Clock
+	start  Date
+	origin Date
+	factor fixed128.Fixed128
+}
+
+// NewScaledClock returns a Clock that reads origin now and then moves
+// factor times as fast as clock. A negative factor runs backwards. It
+// returns an error for NaN, infinities and factors of 2^64 or more.
+func NewScaledClock(clock Clock, origin Date, factor float64) (*ScaledClock, error) {
+	f, err := fixed128.FromFloat64(factor)
+	if err != nil {
+		return nil, fmt.Errorf("clock speed %v: %w", factor, err)
+	}
+	return &ScaledClock{clock: clock, start: clock.Now(), origin: origin, factor: f}, nil
+}
+
+func (c *ScaledClock) Now() Date {
+	elapsed := c.clock.Now().Sub(c.start)
+	return c.origin.Add(Duration{value: elapsed.value.MulSaturating(c.factor)})
+}
@@ -0,0 +1,110 @@
+package binarytime
+
+import (
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/seannyphoenix/binarytime/pkg/fixed128"
+)
+
+func TestManualClock(t *testing.T) {
+	// A hexsecond before midnight
+	start := Date{value: fixed128.FromParts(0x40000004e2c, 0xfff0000000000000, false)}
+	hexSecond := Duration{value: fixed128.FromParts(0, 1<<52, false)}
+
+	c := NewManualClock(start)
+	if got := c.Now(); got != start {
+		t.Fatalf("Now() = %v, want %v", got.value, start.value)
+	}
+
+	next := c.Advance(hexSecond)
+	if got := c.Now(); got != next || got.Truncate(GranularityDay) != start.Truncate(GranularityDay).Add(Duration{value: fixed128.One}) {
+		t.Errorf("Now() after Advance() = %v, want the next midnight", got.value)
+	}
+	if got := c.Advance(hexSecond.Neg()); got != start {
+		t.Errorf("Advance() backwards = %v, want %v", got.value, start.value)
+	}
+
+	c.SetTo(Date{})
+	if got := c.Now(); !got.IsZero() {
+		t.Errorf("Now() after SetTo() = %v, want the zero date", got.value)
+	}
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				c.Advance(hexSecond)
+				c.Now()
+			}
+		}()
+	}
+	wg.Wait()
+	if got, want := c.Now(), (Date{}).Add(hexSecond.Mul(800)); got != want {
+		t.Errorf("Now() after concurrent Advance() = %v, want %v", got.value, want.value)
+	}
+}
+
+func TestOffsetClock(t *testing.T) {
+	base := NewManualClock(DateFromTime(time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)))
+	c := OffsetClock{Clock: base, Offset: FromDuration(-time.Hour)}
+
+	if got, want := c.Now().Time(), time.Date(2025, time.May, 31, 23, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Now() = %v, want %v", got, want)
+	}
+	base.Advance(FromDuration(time.Hour))
+	if got, want := c.Now().Time(), time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Now() after Advance() = %v, want %v", got, want)
+	}
+}
+
+func TestScaledClock(t *testing.T) {
+	base := NewManualClock(DateFromTime(time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)))
+	origin := Date{value: fixed128.FromParts(0x1000, 0, false)}
+
+	tt := []struct {
+		factor  float64
+		advance Duration
+		want    Date
+	}{
+		{1, Duration{value: fixed128.FromParts(0, 1<<60, false)}, Date{value: fixed128.FromParts(0x1000, 1<<60, false)}},
+		{16, Duration{value: fixed128.FromParts(0, 1<<60, false)}, Date{value: fixed128.FromParts(0x1001, 0, false)}},
+		{0.5, Duration{value: fixed128.FromParts(1, 0, false)}, Date{value: fixed128.FromParts(0x1000, 1<<63, false)}},
+		{-2, Duration{value: fixed128.FromParts(1, 0, false)}, Date{value: fixed128.FromParts(0xffe, 0, false)}},
+		{0, Duration{value: fixed128.FromParts(1, 0, false)}, origin},
+	}
+
+	for _, tc := range tt {
+		c, err := NewScaledClock(base, origin, tc.factor)
+		if err != nil {
+			t.Fatalf("NewScaledClock() with factor %v error = %v", tc.factor, err)
+		}
+		if got := c.Now(); got != origin {
+			t.Errorf("Now() with factor %v = %v, want %v", tc.factor, got.value, origin.value)
+		}
+		base.Advance(tc.advance)
+		if got := c.Now(); got != tc.want {
+			t.Errorf("Now() with factor %v after Advance() = %v, want %v", tc.factor, got.value, tc.want.value)
+		}
+	}
+
+	for _, factor := range []float64{math.NaN(), math.Inf(1), 0x1p64} {
+		if c, err := NewScaledClock(base, origin, factor); err == nil {
+			t.Errorf("NewScaledClock() with factor %v = %v, want an error", factor, c)
+		}
+	}
+}
+
+func TestNowUsesSystemClock(t *testing.T) {
+	defer func(c Clock) { SystemClock = c }(SystemClock)
+
+	want := Date{value: fixed128.FromParts(0x40000004e2c, 0, false)}
+	SystemClock = NewManualClock(want)
+	if got := Now(); got != want {
+		t.Errorf("Now() = %v, want %v", got.value, want.value)
+	}
+}
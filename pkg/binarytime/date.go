@@ -1,52 +1,156 @@
 package binarytime
 
 import (
+	"errors"
+	"fmt"
+	"iter"
+	"math"
 	"time"
 
+	"github.com/seannyphoenix/binarytime/internal/intmath"
 	"github.com/seannyphoenix/binarytime/pkg/fixed128"
 )
 
+// conversionRounding is the rounding mode used when converting between
+// nanoseconds and binary time. Rounding to nearest guarantees that
+// DateFromUnixNanos followed by UnixNano returns the original value for
+// every int64.
+const conversionRounding = fixed128.RoundHalfEven
+
 var (
 	BinaryTimeOffset = fixed128.FromParts(1<<42, 0, false)
+
+	// ErrOutOfRange is returned by conversions to types that cannot
+	// represent the whole range of a Date.
+	ErrOutOfRange = errors.New("binary time out of range")
+)
+
+const (
+	secondsPerDay = 86_400
+
+	// maxUnixSeconds is the last second that time.Time can hold, about
+	// 292 billion years after 1970, and maxUnixDays the last whole day.
+	maxUnixSeconds = math.MaxInt64 - 62_135_596_800
+	maxUnixDays    = maxUnixSeconds/secondsPerDay - 1
 )
 
 type Date struct {
 	value fixed128.Fixed128
 }
 
+// Now returns the current Date, as told by SystemClock.
 func Now() Date {
-	return DateFromTime(time.Now())
+	return SystemClock.Now()
 }
 
+// DateFromTime returns the Date of t. Times before the zero date,
+// about 12 billion years ago, saturate to the zero date.
 func DateFromTime(t time.Time) Date {
-	return DateFromUnixNanos(t.UnixNano())
+	return DateFromUnix(t.Unix(), int64(t.Nanosecond()))
 }
 
-// DateFromUnixNanos creates a BinaryTime from a Unix timestamp in nanoseconds.
-func DateFromUnixNanos(nanos int64) Date {
-	value, err := fixed128.ByDivision(nanos, dayNs)
-	if err != nil {
+// DateFromUnix creates a Date from a Unix timestamp of sec seconds and
+// nsec nanoseconds, which may be outside [0, 999999999]. Unlike
+// DateFromUnixNanos it covers every time.Time, saturating to the zero
+// date before it.
+func DateFromUnix(sec, nsec int64) Date {
+	sec, nsec = normalizeUnix(sec, nsec)
+	days := intmath.FloorDiv(sec, secondsPerDay)
+	dayNanos := (sec-days*secondsPerDay)*1e9 + nsec
+
+	frac, _ := fixed128.ByDivisionRounded(dayNanos, dayNs, conversionRounding)
+	whole := fixed128.FromParts(uint64(days), 0, false)
+	if days < 0 {
+		whole = fixed128.FromParts(uint64(-days), 0, true)
+	}
+	v := BinaryTimeOffset.AddSaturating(whole).AddSaturating(frac)
+	if v.Sign() {
 		return Date{}
 	}
+	return Date{value: v}
+}
+
+// normalizeUnix carries whole seconds out of nsec, saturating sec.
+func normalizeUnix(sec, nsec int64) (int64, int64) {
+	carry := intmath.FloorDiv(nsec, 1e9)
+	nsec -= carry * 1e9
+	switch {
+	case carry > 0 && sec > math.MaxInt64-carry:
+		return math.MaxInt64, nsec
+	case carry < 0 && sec < math.MinInt64-carry:
+		return math.MinInt64, nsec
+	}
+	return sec + carry, nsec
+}
+
+// Unix returns the Date as a Unix timestamp in seconds and nanoseconds,
+// with nsec in [0, 999999999]. Dates after the last time.Time, about
+// 292 billion years after 1970, saturate to it.
+func (d Date) Unix() (sec, nsec int64) {
+	days, frac, ok := d.unixDays()
+	if !ok {
+		return maxUnixSeconds, 999_999_999
+	}
+	dayNanos, _ := frac.MulInt64Rounded(dayNs, conversionRounding)
+	return days*secondsPerDay + dayNanos/1e9, dayNanos % 1e9
+}
+
+// unixDays splits the Date into whole days since 1970, rounded down,
+// and the fraction of the day. It reports false for Dates after the
+// last day time.Time can hold.
+func (d Date) unixDays() (int64, fixed128.Fixed128, bool) {
+	hi, lo, neg := d.value.SubSaturating(BinaryTimeOffset).Parts()
+	switch {
+	case !neg:
+		return int64(hi), fixed128.FromParts(0, lo, false), hi <= maxUnixDays
+	case lo == 0:
+		return -int64(hi), fixed128.Zero, true
+	default:
+		return -int64(hi) - 1, fixed128.FromParts(0, -lo, false), true
+	}
+}
+
+// ToTime is like Time, but returns an ErrOutOfRange error rather than
+// saturating when the Date is beyond what time.Time can hold.
+func (d Date) ToTime() (time.Time, error) {
+	if _, _, ok := d.unixDays(); !ok {
+		return time.Time{}, fmt.Errorf("%w: %v is after time.Time's range", ErrOutOfRange, d.value)
+	}
+	return time.Unix(d.Unix()), nil
+}
 
-	value, err = value.Add(BinaryTimeOffset)
+// DateFromUnixNanos creates a BinaryTime from a Unix timestamp in nanoseconds.
+func DateFromUnixNanos(nanos int64) Date {
+	value, err := fixed128.ByDivisionRounded(nanos, dayNs, conversionRounding)
 	if err != nil {
 		return Date{}
 	}
 
-	return Date{value: value}
+	return Date{value: value.AddSaturating(BinaryTimeOffset)}
 }
 
+// Time returns the Date as a time.Time in the local time zone. Dates
+// after what time.Time can hold saturate; use ToTime to detect them.
 func (d Date) Time() time.Time {
-	return time.Unix(0, d.UnixNano())
+	t, err := d.ToTime()
+	if err != nil {
+		return time.Unix(maxUnixSeconds, 999_999_999)
+	}
+	return t
 }
 
+// UnixNano returns the Date as a Unix timestamp in nanoseconds.
+// Dates beyond the range of an int64, about 292 years either side
+// of 1970, saturate to math.MaxInt64 or math.MinInt64.
 func (d Date) UnixNano() int64 {
-	v, err := d.value.Sub(BinaryTimeOffset)
+	v := d.value.SubSaturating(BinaryTimeOffset)
+	ns, err := v.MulInt64Rounded(dayNs, conversionRounding)
 	if err != nil {
-		return 0
+		if v.Sign() {
+			return math.MinInt64
+		}
+		return math.MaxInt64
 	}
-	ns, _ := v.MulInt64(dayNs)
 	return ns
 }
 
@@ -60,6 +164,84 @@ func (d Date) Equals(other Date) bool {
 	return d.value.Cmp(other.value) == 0
 }
 
+// Add returns the Date shifted by duration. The result saturates at the
+// zero Date, the earliest representable, and at the latest representable Date.
+func (d Date) Add(duration Duration) Date {
+	value := d.value.AddSaturating(duration.value)
+	if value.Sign() {
+		return Date{}
+	}
+	return Date{value: value}
+}
+
+// Sub returns the Duration d-other. Dates are never more than
+// 2^64 days apart, so the result is always exact.
+func (d Date) Sub(other Date) Duration {
+	return Duration{value: d.value.SubSaturating(other.value)}
+}
+
+// Compare compares two Dates, returning -1 if d is before other, 0 if
+// they are equal and 1 if d is after other. It can be passed directly
+// to slices.SortFunc.
+func (d Date) Compare(other Date) int {
+	return d.value.Cmp(other.value)
+}
+
+// Before reports whether d is before other.
+func (d Date) Before(other Date) bool {
+	return d.Compare(other) < 0
+}
+
+// After reports whether d is after other.
+func (d Date) After(other Date) bool {
+	return d.Compare(other) > 0
+}
+
+// Min returns the earliest of the given Dates.
+func Min(first Date, rest ...Date) Date {
+	for _, d := range rest {
+		if d.Before(first) {
+			first = d
+		}
+	}
+	return first
+}
+
+// Max returns the latest of the given Dates.
+func Max(first Date, rest ...Date) Date {
+	for _, d := range rest {
+		if d.After(first) {
+			first = d
+		}
+	}
+	return first
+}
+
+// Range returns the Dates from start up to but not including end, separated
+// by step. A negative step counts down from start to end. The sequence is
+// empty if step is zero or points away from end.
+func Range(start, end Date, step Duration) iter.Seq[Date] {
+	return func(yield func(Date) bool) {
+		dir := step.Cmp(Duration{})
+		if dir == 0 {
+			return
+		}
+
+		for d := start; d.Compare(end) == -dir; {
+			if !yield(d) {
+				return
+			}
+
+			// Stop rather than repeat a Date once Add saturates
+			next := d.Add(step)
+			if next.Compare(d) != dir {
+				return
+			}
+			d = next
+		}
+	}
+}
+
 // Fixed128 returns the underlying Fixed128 value of the Date.
 // This is a copy of the value, not a reference.
 func (d Date) Fixed128() fixed128.Fixed128 {
@@ -70,11 +252,19 @@ func (d Date) Bytes() []byte {
 	return d.value.Bytes()
 }
 
+// DateFromFixed128 returns the Date the given number of days after the
+// zero date. Negative values are before the zero date and are an error.
+func DateFromFixed128(value fixed128.Fixed128) (Date, error) {
+	if value.Sign() {
+		return Date{}, fmt.Errorf("%w: %v is before the zero date", ErrInvalidBinaryTimeFormat, value)
+	}
+	return Date{value: value}, nil
+}
+
 func DateFromBytes(b []byte) (Date, error) {
 	value, err := fixed128.FromBytes(b)
-	d := Date{value: value}
 	if err != nil {
-		return d, err
+		return Date{}, err
 	}
-	return d, nil
+	return DateFromFixed128(value)
 }
@@ -1,9 +1,229 @@
 package binarytime
 
-import "testing"
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/seannyphoenix/binarytime/pkg/fixed128"
+)
 
 func BenchmarkNow(b *testing.B) {
 	for b.Loop() {
 		_ = Now()
 	}
 }
+
+func TestDateUnixNanoRoundTrip(t *testing.T) {
+	tt := []int64{
+		0,
+		1,
+		-1,
+		1_700_000_000_123_456_789,
+		-1_700_000_000_123_456_789,
+		math.MaxInt64,
+		math.MinInt64,
+	}
+
+	for _, nanos := range tt {
+		got := DateFromUnixNanos(nanos).UnixNano()
+		if got != nanos {
+			t.Errorf("DateFromUnixNanos(%d).UnixNano() = %d", nanos, got)
+		}
+	}
+}
+
+func TestDateUnixNanoSaturates(t *testing.T) {
+	tt := []struct {
+		name string
+		date Date
+		want int64
+	}{
+		{"zero date", Date{}, math.MinInt64},
+		{"max date", Date{value: fixed128.Max}, math.MaxInt64},
+		{"a day past int64", Date{value: DateFromUnixNanos(math.MaxInt64).value.AddSaturating(fixed128.One)}, math.MaxInt64},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.date.UnixNano(); got != tc.want {
+				t.Errorf("UnixNano() = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func FuzzDateUnixNanoRoundTrip(f *testing.F) {
+	f.Add(int64(0))
+	f.Add(int64(999_999_999))
+	f.Add(int64(math.MaxInt64))
+	f.Add(int64(math.MinInt64))
+
+	f.Fuzz(func(t *testing.T, nanos int64) {
+		got := DateFromUnixNanos(nanos).UnixNano()
+		if got != nanos {
+			t.Fatalf("DateFromUnixNanos(%d).UnixNano() = %d", nanos, got)
+		}
+	})
+}
+
+func TestDateAddSub(t *testing.T) {
+	epoch := DateFromUnixNanos(0)
+	hour := FromDuration(time.Hour)
+
+	if got := epoch.Add(hour).UnixNano(); got != int64(time.Hour) {
+		t.Errorf("Add() = %d ns, want %d", got, int64(time.Hour))
+	}
+	if got := epoch.Add(hour.Neg()).Sub(epoch); !got.Equals(hour.Neg()) {
+		t.Errorf("Sub() = %v, want %v", got, hour.Neg())
+	}
+
+	// A tick is the smallest step, which int64 nanoseconds cannot express
+	tick := Duration{value: fixed128.FromParts(0, 1, false)}
+	if got := epoch.Add(tick).Sub(epoch); !got.Equals(tick) {
+		t.Errorf("Sub() = %v, want %v", got, tick)
+	}
+
+	latest := Date{value: fixed128.Max}
+	if got := latest.Add(hour); got != latest {
+		t.Errorf("Add() past the latest date = %v, want %v", got.value, latest.value)
+	}
+	if got := epoch.Add(Duration{value: fixed128.Min}); got != (Date{}) {
+		t.Errorf("Add() before the zero date = %v, want zero", got.value)
+	}
+}
+
+func TestDateCompare(t *testing.T) {
+	a := DateFromUnixNanos(1)
+	b := DateFromUnixNanos(2)
+	c := DateFromUnixNanos(-3)
+
+	if !a.Before(b) || a.After(b) || !b.After(a) || a.Compare(a) != 0 {
+		t.Errorf("Before/After/Compare(%v, %v) are inconsistent", a.value, b.value)
+	}
+
+	dates := []Date{b, c, a}
+	slices.SortFunc(dates, Date.Compare)
+	if !slices.Equal(dates, []Date{c, a, b}) {
+		t.Errorf("SortFunc() = %v", dates)
+	}
+
+	if got := Min(b, c, a); got != c {
+		t.Errorf("Min() = %v, want %v", got.value, c.value)
+	}
+	if got := Max(b, c, a); got != b {
+		t.Errorf("Max() = %v, want %v", got.value, b.value)
+	}
+	if got := Max(a); got != a {
+		t.Errorf("Max() = %v, want %v", got.value, a.value)
+	}
+}
+
+func TestRange(t *testing.T) {
+	hour := FromDuration(time.Hour)
+	at := func(hours int64) Date { return DateFromUnixNanos(0).Add(hour.Mul(hours)) }
+	latest := Date{value: fixed128.Max}
+
+	tt := []struct {
+		name       string
+		start, end Date
+		step       Duration
+		want       []Date
+	}{
+		{"forward", at(0), at(3), hour, []Date{at(0), at(1), at(2)}},
+		{"forward uneven", at(0), at(3), hour.Mul(2), []Date{at(0), at(2)}},
+		{"backward", at(3), at(0), hour.Neg(), []Date{at(3), at(2), at(1)}},
+		{"empty", at(0), at(0), hour, nil},
+		{"zero step", at(0), at(3), Duration{}, nil},
+		{"wrong direction", at(0), at(3), hour.Neg(), nil},
+		{"stops at the latest date", latest.Add(hour.Neg()), latest, hour.Mul(2), []Date{latest.Add(hour.Neg())}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got := slices.Collect(Range(tc.start, tc.end, tc.step))
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("Range() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDateUnix(t *testing.T) {
+	tt := []struct {
+		name string
+		sec  int64
+		nsec int64
+		want Date
+	}{
+		{"epoch", 0, 0, DateFromUnixNanos(0)},
+		{"negative nanoseconds", 1, -1, DateFromUnixNanos(999_999_999)},
+		{"carried nanoseconds", -2, 2_500_000_000, DateFromUnixNanos(500_000_000)},
+		{"before 1970", -86_400, 0, Date{value: fixed128.FromParts(1<<42-1, 0, false)}},
+		{"a million years on", 31_557_600_000_000, 0, Date{value: fixed128.FromParts(1<<42+365_250_000, 0, false)}},
+		{"before the zero date", math.MinInt64, 0, Date{}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got := DateFromUnix(tc.sec, tc.nsec)
+			if got != tc.want {
+				t.Fatalf("DateFromUnix() = %v, want %v", got.value, tc.want.value)
+			}
+			if tc.want.IsZero() {
+				return
+			}
+
+			sec, nsec := got.Unix()
+			if wantSec, wantNsec := normalizeUnix(tc.sec, tc.nsec); sec != wantSec || nsec != wantNsec {
+				t.Errorf("Unix() = %d, %d, want %d, %d", sec, nsec, wantSec, wantNsec)
+			}
+		})
+	}
+}
+
+func TestDateToTime(t *testing.T) {
+	far := time.Date(1_000_000, time.March, 4, 5, 6, 7, 8, time.UTC)
+	d := DateFromTime(far)
+	if got, err := d.ToTime(); err != nil || !got.Equal(far) {
+		t.Errorf("ToTime() = %v, %v, want %v", got, err, far)
+	}
+	if got := d.Time(); !got.Equal(far) {
+		t.Errorf("Time() = %v, want %v", got, far)
+	}
+
+	first, err := Date{}.ToTime()
+	if err != nil || DateFromTime(first) != (Date{}) {
+		t.Errorf("ToTime() of the zero date = %v, %v", first, err)
+	}
+
+	latest := Date{value: fixed128.Max}
+	if _, err := latest.ToTime(); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("ToTime() of the latest date error = %v, want ErrOutOfRange", err)
+	}
+	if sec, nsec := latest.Unix(); sec != maxUnixSeconds || nsec != 999_999_999 {
+		t.Errorf("Unix() of the latest date = %d, %d, want the last time.Time", sec, nsec)
+	}
+	if got := latest.Time(); got.Unix() != maxUnixSeconds {
+		t.Errorf("Time() of the latest date = %v, want the last time.Time", got)
+	}
+}
+
+func FuzzDateUnixRoundTrip(f *testing.F) {
+	f.Add(int64(0), int64(0))
+	f.Add(int64(-380_000_000_000_000_000), int64(1))
+	f.Add(int64(maxUnixSeconds-2*secondsPerDay), int64(999_999_999))
+
+	f.Fuzz(func(t *testing.T, sec, nsec int64) {
+		nsec = (nsec%1e9 + 1e9) % 1e9
+		d := DateFromUnix(sec, nsec)
+		if d.IsZero() || sec > maxUnixDays*secondsPerDay {
+			return
+		}
+		if gotSec, gotNsec := d.Unix(); gotSec != sec || gotNsec != nsec {
+			t.Fatalf("DateFromUnix(%d, %d).Unix() = %d, %d", sec, nsec, gotSec, gotNsec)
+		}
+	})
+}
@@ -2,16 +2,19 @@ package binarytime
 
 import (
 	"encoding"
-	"encoding/binary"
 	"errors"
 	"fmt"
+	"math/bits"
 	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/seannyphoenix/binarytime/pkg/byteglyph"
 	"github.com/seannyphoenix/binarytime/pkg/fixed128"
 )
 
 var (
+	// BinaryTimeRegexp matches the canonical text form produced by MarshalText.
 	BinaryTimeRegexp           = regexp.MustCompile(`^@[0-9a-f]{16}\.[0-9a-f]{16}$`)
 	ErrInvalidBinaryTimeFormat = errors.New("invalid binary time format")
 )
@@ -24,23 +27,96 @@ var (
 	_ encoding.TextUnmarshaler = (*Date)(nil)
 )
 
-func (d Date) MarshalText() ([]byte, error) {
-	hi := d.value.Bytes()[0:8]
-	lo := d.value.Bytes()[8:16]
+// ParseError describes a malformed binary time string. It wraps
+// ErrInvalidBinaryTimeFormat, so errors.Is can be used to test for it.
+type ParseError struct {
+	Text   string // the text being parsed
+	Offset int    // byte offset of the problem within Text
+	Msg    string // what was wrong at Offset
+}
 
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%s: %q at offset %d: %s", ErrInvalidBinaryTimeFormat, e.Text, e.Offset, e.Msg)
+}
+
+func (e *ParseError) Unwrap() error {
+	return ErrInvalidBinaryTimeFormat
+}
+
+// MarshalText returns the canonical form of the Date, an '@' followed by
+// 16 hex digits of whole days, a period and 16 hex digits of the day.
+func (d Date) MarshalText() ([]byte, error) {
+	hi, lo, _ := d.value.Parts()
 	return fmt.Appendf(nil, "@%016x.%016x", hi, lo), nil
 }
 
+// UnmarshalText parses the form produced by MarshalText. It also accepts
+// upper-case digits, a missing '@', leading zeros omitted from the whole
+// days, and a fraction shortened or left out entirely, so "@4e2c.8" is the
+// same as "@0000000000004e2c.8000000000000000".
 func (d *Date) UnmarshalText(text []byte) error {
-	if !BinaryTimeRegexp.Match(text) {
-		return fmt.Errorf("%w: %s", ErrInvalidBinaryTimeFormat, text)
+	value, err := parseHexDate(string(text))
+	if err != nil {
+		return err
 	}
+	d.value = value
+	return nil
+}
 
-	hi := binary.BigEndian.Uint64(text[1:17])
-	lo := binary.BigEndian.Uint64(text[18:34])
+func parseHexDate(s string) (fixed128.Fixed128, error) {
+	i := 0
+	if i < len(s) && s[i] == '@' {
+		i++
+	}
 
-	d.value = fixed128.FromParts(hi, lo, false)
-	return nil
+	hi, n := parseHexDigits(s[i:])
+	switch {
+	case n == 0:
+		return fixed128.Zero, &ParseError{Text: s, Offset: i, Msg: "expected hex digit"}
+	case n > 16:
+		return fixed128.Zero, &ParseError{Text: s, Offset: i + 16, Msg: "more than 16 digits of whole days"}
+	}
+	i += n
+
+	var lo uint64
+	if i < len(s) && s[i] == '.' {
+		i++
+		lo, n = parseHexDigits(s[i:])
+		if n > 16 {
+			return fixed128.Zero, &ParseError{Text: s, Offset: i + 16, Msg: "more than 16 digits of fraction"}
+		}
+		lo <<= 4 * (16 - n)
+		i += n
+	}
+
+	if i < len(s) {
+		return fixed128.Zero, &ParseError{Text: s, Offset: i, Msg: fmt.Sprintf("unexpected %q", s[i:i+1])}
+	}
+	return fixed128.FromParts(hi, lo, false), nil
+}
+
+// parseHexDigits reads the hex digits at the start of s, returning their
+// count and the value of the first 16.
+func parseHexDigits(s string) (uint64, int) {
+	var v uint64
+	n := 0
+	for ; n < len(s); n++ {
+		var digit byte
+		switch c := s[n]; {
+		case c >= '0' && c <= '9':
+			digit = c - '0'
+		case c >= 'a' && c <= 'f':
+			digit = c - 'a' + 10
+		case c >= 'A' && c <= 'F':
+			digit = c - 'A' + 10
+		default:
+			return v, n
+		}
+		if n < 16 {
+			v = v<<4 | uint64(digit)
+		}
+	}
+	return v, n
 }
 
 func (d Date) MarshalBinary() ([]byte, error) {
@@ -48,11 +124,11 @@ func (d Date) MarshalBinary() ([]byte, error) {
 }
 
 func (d *Date) UnmarshalBinary(data []byte) error {
-	value, err := fixed128.FromBytes(data)
+	date, err := DateFromBytes(data)
 	if err != nil {
 		return err
 	}
-	d.value = value
+	*d = date
 	return nil
 }
 
@@ -83,3 +159,315 @@ func (d Date) DateGlyphs() string {
 func (d Date) DateTimeGlyphs() string {
 	return byteglyph.Glyphs(d.Bytes()[6:10], 2)
 }
+
+// Layouts for Date.Format and Parse. A layout is literal text with
+// directives introduced by '%' and an optional digit count:
+//
+//	%D  whole days as 16 hex digits, or only the last n with %nD
+//	%d  whole days in decimal, zero-padded to n digits with %nd
+//	%x  the fraction of the day in hex, 16 digits unless counted
+//	%o  the fraction in octal, 22 digits unless counted
+//	%b  the fraction in binary, 64 digits unless counted
+//	%q  the fraction in quad (base 4) digits as drawn by the GUI, 32 unless counted
+//	%f  the fraction in decimal, 20 digits unless counted, truncated
+//	%g  byte-glyphs of the two bytes either side of the point, as DateTimeGlyphs
+//	%%  a literal '%'
+//
+// Each %x, %o, %b and %q picks up from the bit where the previous one
+// stopped, so "%1x:%1x" shows the hexhour and then the hexminute, while %f
+// always starts at the point. Twenty decimal digits are enough for Parse
+// to recover every tick exactly.
+const (
+	LayoutCanonical = "@%D.%x"          // the MarshalText form
+	LayoutHex       = "%D.%x"           // the JSON and TypeScript form
+	LayoutClock     = "%4D.%1x:%1x:%1x" // day, hexhour, hexminute and hexsecond
+	LayoutDecimal   = "%d.%f"
+	LayoutQuad      = "%4D.%8q"
+	LayoutGlyphs    = "%g"
+)
+
+// fractionDigits holds the bits per digit and default digit count
+// of the directives that print the fraction bit by bit.
+var fractionDigits = map[byte]struct{ bits, count int }{
+	'x': {4, 16},
+	'o': {3, 22},
+	'b': {1, 64},
+	'q': {2, 32},
+}
+
+// glyphsLen is the length of every %g block.
+var glyphsLen = len(byteglyph.Glyphs(make([]byte, 4), 2))
+
+// maxDirectiveCount caps the digit count of a directive, a little over
+// the 64 bits of the fraction, so that a layout can't ask for unbounded output.
+const maxDirectiveCount = 72
+
+type directive struct {
+	verb  byte // 0 once the layout is exhausted
+	count int  // -1 if no count was given
+}
+
+func (dir directive) countOr(def int) int {
+	if dir.count < 0 {
+		return def
+	}
+	return dir.count
+}
+
+// nextDirective splits the literal text at layout[i:] from the directive
+// that follows it, and returns the index just past the directive. A count
+// above maxDirectiveCount is reported as a *ParseError within layout.
+func nextDirective(layout string, i int) (string, directive, int, error) {
+	j := strings.IndexByte(layout[i:], '%')
+	if j < 0 {
+		return layout[i:], directive{}, len(layout), nil
+	}
+	literal := layout[i : i+j]
+	start := i + j + 1
+
+	dir := directive{count: -1}
+	k := start
+	for k < len(layout) && layout[k] >= '0' && layout[k] <= '9' {
+		k++
+	}
+	var err error
+	if k > start {
+		var convErr error
+		dir.count, convErr = strconv.Atoi(layout[start:k])
+		if convErr != nil || dir.count > maxDirectiveCount {
+			err = &ParseError{Text: layout, Offset: start, Msg: fmt.Sprintf("count above %d", maxDirectiveCount)}
+		}
+	}
+	if k < len(layout) {
+		dir.verb = layout[k]
+		k++
+	} else {
+		// A trailing '%' is an unknown directive, not the end of the layout
+		dir.verb = '%'
+		dir.count = -2
+	}
+	return literal, dir, k, err
+}
+
+// ValidateLayout reports whether layout can be used with Format and Parse:
+// every directive must be known and have a count no greater than 72.
+// Errors are *ParseError values positioned within layout.
+func ValidateLayout(layout string) error {
+	for i := 0; i < len(layout); {
+		_, dir, next, err := nextDirective(layout, i)
+		if err != nil {
+			return err
+		}
+		switch dir.verb {
+		case 0, 'D', 'd', 'x', 'o', 'b', 'q', 'f', 'g':
+		case '%':
+			if dir.count == -1 {
+				break
+			}
+			fallthrough
+		default:
+			return &ParseError{Text: layout, Offset: next - 1, Msg: fmt.Sprintf("unknown layout directive %%%c", dir.verb)}
+		}
+		i = next
+	}
+	return nil
+}
+
+// fractionBits returns the k bits starting pos bits below the point,
+// reading zeros past the last tick.
+func fractionBits(lo uint64, pos, k int) uint64 {
+	mask := uint64(1)<<k - 1
+	switch shift := 64 - pos - k; {
+	case pos >= 64:
+		return 0
+	case shift >= 0:
+		return lo >> shift & mask
+	default:
+		return lo << -shift & mask
+	}
+}
+
+// setFractionBits stores v as the k bits starting pos bits below the
+// point, dropping any that fall past the last tick.
+func setFractionBits(lo uint64, pos, k int, v uint64) uint64 {
+	if pos >= 64 {
+		return lo
+	}
+	mask := uint64(1)<<k - 1
+	shift := 64 - pos - k
+	if shift < 0 {
+		return lo&^(mask>>-shift) | v>>-shift
+	}
+	return lo&^(mask<<shift) | v<<shift
+}
+
+// Format returns the Date rendered according to layout; see LayoutCanonical
+// for the directives. Unknown directives, and those with a count above 72,
+// are printed as "%!" and the verb. Use ValidateLayout to check a layout
+// from an untrusted source first.
+func (d Date) Format(layout string) string {
+	hi, lo, _ := d.value.Parts()
+	var b []byte
+	cursor := 0
+
+	for i := 0; i < len(layout); {
+		literal, dir, next, err := nextDirective(layout, i)
+		b = append(b, literal...)
+		i = next
+		if err != nil {
+			b = append(b, '%', '!', dir.verb)
+			continue
+		}
+
+		switch dir.verb {
+		case 0:
+		case 'D':
+			n := min(max(dir.countOr(16), 1), 16)
+			b = append(b, fmt.Sprintf("%016x", hi)[16-n:]...)
+		case 'd':
+			b = fmt.Appendf(b, "%0*d", dir.countOr(0), hi)
+		case 'x', 'o', 'b', 'q':
+			k := fractionDigits[dir.verb].bits
+			for range dir.countOr(fractionDigits[dir.verb].count) {
+				b = strconv.AppendUint(b, fractionBits(lo, cursor, k), 1<<k)
+				cursor += k
+			}
+		case 'f':
+			frac := lo
+			for range dir.countOr(20) {
+				var digit uint64
+				digit, frac = bits.Mul64(frac, 10)
+				b = append(b, byte('0'+digit))
+			}
+		case 'g':
+			b = append(b, byteglyph.Glyphs(d.Bytes()[6:10], 2)...)
+		case '%':
+			if dir.count == -1 {
+				b = append(b, '%')
+				break
+			}
+			fallthrough
+		default:
+			b = append(b, '%', '!', dir.verb)
+		}
+	}
+	return string(b)
+}
+
+// Parse reads a Date written in the given layout, the reverse of Format.
+// Parts of the Date that the layout leaves out are zero. Errors are
+// *ParseError values positioned within value, or within layout if a
+// directive's count is above 72.
+func Parse(layout, value string) (Date, error) {
+	var hi, lo, carry uint64
+	cursor := 0
+	i := 0
+
+	fail := func(offset int, format string, args ...any) (Date, error) {
+		return Date{}, &ParseError{Text: value, Offset: offset, Msg: fmt.Sprintf(format, args...)}
+	}
+
+	// digits reads exactly n digits of the given base at i
+	digits := func(n, base int) (string, bool) {
+		j := i
+		for j < len(value) && j-i < n {
+			if d, err := strconv.ParseUint(value[j:j+1], base, 8); err != nil || int(d) >= base {
+				break
+			}
+			j++
+		}
+		return value[i:j], j-i == n
+	}
+
+	for l := 0; l < len(layout); {
+		literal, dir, next, err := nextDirective(layout, l)
+		if err != nil {
+			return Date{}, err
+		}
+		l = next
+
+		if !strings.HasPrefix(value[i:], literal) {
+			j := 0
+			for i+j < len(value) && value[i+j] == literal[j] {
+				j++
+			}
+			return fail(i+j, "expected %q", literal)
+		}
+		i += len(literal)
+
+		switch dir.verb {
+		case 0:
+		case 'D':
+			n := min(max(dir.countOr(16), 1), 16)
+			s, ok := digits(n, 16)
+			if !ok {
+				return fail(i+len(s), "expected %d hex digits of days", n)
+			}
+			v, _ := strconv.ParseUint(s, 16, 64)
+			mask := ^uint64(0) >> (64 - 4*n)
+			hi = hi&^mask | v
+			i += n
+		case 'd':
+			s, _ := digits(20, 10)
+			if s == "" || len(s) < dir.countOr(0) {
+				return fail(i+len(s), "expected decimal days")
+			}
+			v, err := strconv.ParseUint(s, 10, 64)
+			if err != nil {
+				return fail(i, "too many days")
+			}
+			hi = v
+			i += len(s)
+		case 'x', 'o', 'b', 'q':
+			k := fractionDigits[dir.verb].bits
+			n := dir.countOr(fractionDigits[dir.verb].count)
+			s, ok := digits(n, 1<<k)
+			if !ok {
+				return fail(i+len(s), "expected %d base-%d digits of the day", n, 1<<k)
+			}
+			for _, c := range []byte(s) {
+				v, _ := strconv.ParseUint(string(c), 1<<k, 8)
+				lo = setFractionBits(lo, cursor, k, v)
+				cursor += k
+			}
+			i += n
+		case 'f':
+			n := dir.countOr(20)
+			s, ok := digits(n, 10)
+			if !ok {
+				return fail(i+len(s), "expected %d decimal digits of the day", n)
+			}
+			frac, _ := fixed128.ParseDecimal("0." + s)
+			carry, lo, _ = frac.Parts()
+			i += n
+		case 'g':
+			if len(value)-i < glyphsLen {
+				return fail(len(value), "incomplete glyphs")
+			}
+			glyphs, dot, err := byteglyph.Parse(value[i : i+glyphsLen])
+			if err != nil || len(glyphs) != 4 || dot != 2 {
+				return fail(i, "unreadable glyphs")
+			}
+			hi = hi&^0xffff | uint64(glyphs[0])<<8 | uint64(glyphs[1])
+			lo = lo&^(0xffff<<48) | uint64(glyphs[2])<<56 | uint64(glyphs[3])<<48
+			i += glyphsLen
+		case '%':
+			if dir.count == -1 {
+				if !strings.HasPrefix(value[i:], "%") {
+					return fail(i, "expected %q", "%")
+				}
+				i++
+				break
+			}
+			fallthrough
+		default:
+			return fail(i, "unknown layout directive %%%c", dir.verb)
+		}
+	}
+
+	if i < len(value) {
+		return fail(i, "unexpected %q", value[i:])
+	}
+	value128 := fixed128.FromParts(hi, lo, false)
+	return Date{value: value128.AddSaturating(fixed128.FromParts(carry, 0, false))}, nil
+}
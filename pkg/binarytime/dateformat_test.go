@@ -0,0 +1,281 @@
+package binarytime
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/seannyphoenix/binarytime/pkg/fixed128"
+)
+
+func TestDateUnmarshalText(t *testing.T) {
+	tt := []struct {
+		text   string
+		want   fixed128.Fixed128
+		offset int
+	}{
+		{"@0000040000004e2c.9f3b7a5c1d2e4f68", fixed128.FromParts(0x40000004e2c, 0x9f3b7a5c1d2e4f68, false), -1},
+		{"@0000040000004E2C.9F3B7A5C1D2E4F68", fixed128.FromParts(0x40000004e2c, 0x9f3b7a5c1d2e4f68, false), -1},
+		{"0000040000004e2c.9f3b7a5c1d2e4f68", fixed128.FromParts(0x40000004e2c, 0x9f3b7a5c1d2e4f68, false), -1},
+		{"@4e2c.8", fixed128.FromParts(0x4e2c, 1<<63, false), -1},
+		{"@4e2c.", fixed128.FromParts(0x4e2c, 0, false), -1},
+		{"@4e2c", fixed128.FromParts(0x4e2c, 0, false), -1},
+		{"@0", fixed128.Zero, -1},
+		{"", fixed128.Zero, 0},
+		{"@", fixed128.Zero, 1},
+		{"@.8", fixed128.Zero, 1},
+		{"@-1.0", fixed128.Zero, 1},
+		{"@@1.0", fixed128.Zero, 1},
+		{"@1.0x", fixed128.Zero, 4},
+		{"@1.0.0", fixed128.Zero, 4},
+		{"@1 ", fixed128.Zero, 2},
+		{"@00000000000000001.0", fixed128.Zero, 17},
+		{"@1.00000000000000001", fixed128.Zero, 19},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.text, func(t *testing.T) {
+			var d Date
+			err := d.UnmarshalText([]byte(tc.text))
+			if tc.offset < 0 {
+				if err != nil {
+					t.Fatalf("UnmarshalText() error = %v", err)
+				}
+				if d.value != tc.want {
+					t.Errorf("UnmarshalText() = %v, want %v", d.value, tc.want)
+				}
+				return
+			}
+
+			var perr *ParseError
+			if !errors.As(err, &perr) || !errors.Is(err, ErrInvalidBinaryTimeFormat) {
+				t.Fatalf("UnmarshalText() error = %v, want a ParseError", err)
+			}
+			if perr.Offset != tc.offset {
+				t.Errorf("UnmarshalText() error offset = %d, want %d: %v", perr.Offset, tc.offset, err)
+			}
+		})
+	}
+}
+
+func FuzzDateTextRoundTrip(f *testing.F) {
+	f.Add(uint64(0), uint64(0))
+	f.Add(uint64(1<<42), uint64(1<<63))
+	f.Add(^uint64(0), ^uint64(0))
+
+	f.Fuzz(func(t *testing.T, hi, lo uint64) {
+		d := Date{value: fixed128.FromParts(hi, lo, false)}
+		text, err := d.MarshalText()
+		if err != nil {
+			t.Fatalf("MarshalText() error = %v", err)
+		}
+		if !BinaryTimeRegexp.Match(text) {
+			t.Fatalf("MarshalText() = %s, which BinaryTimeRegexp rejects", text)
+		}
+
+		var got Date
+		if err := got.UnmarshalText(text); err != nil || got != d {
+			t.Fatalf("UnmarshalText(%s) = %v, %v, want %v", text, got.value, err, d.value)
+		}
+	})
+}
+
+func FuzzDateUnmarshalText(f *testing.F) {
+	f.Add("@0000040000004e2c.9f3b7a5c1d2e4f68")
+	f.Add("4E2C.8")
+	f.Add("@.")
+
+	f.Fuzz(func(t *testing.T, s string) {
+		var d Date
+		if err := d.UnmarshalText([]byte(s)); err != nil {
+			var perr *ParseError
+			if !errors.As(err, &perr) || perr.Offset < 0 || perr.Offset > len(s) {
+				t.Fatalf("UnmarshalText(%q) error = %v", s, err)
+			}
+			return
+		}
+
+		// Anything accepted must survive a trip through the canonical form
+		text, _ := d.MarshalText()
+		var got Date
+		if err := got.UnmarshalText(text); err != nil || got != d {
+			t.Fatalf("UnmarshalText(%s) = %v, %v, want %v", text, got.value, err, d.value)
+		}
+	})
+}
+
+func TestDateUnmarshalBinary(t *testing.T) {
+	d := Date{value: fixed128.FromParts(0x40000004e2c, 1<<63, false)}
+
+	tt := []struct {
+		name string
+		data []byte
+		want Date
+		err  error
+	}{
+		{"legacy", d.Bytes(), d, nil},
+		{"version 1", append([]byte{0x01}, d.Bytes()...), d, nil},
+		{"negative", append([]byte{0x81}, d.Bytes()...), Date{}, ErrInvalidBinaryTimeFormat},
+		{"short", d.Bytes()[1:], Date{}, fixed128.ErrorBadByteLength},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := DateFromBytes(tc.data)
+			if !errors.Is(err, tc.err) || got != tc.want {
+				t.Errorf("DateFromBytes(%x) = %v, %v, want %v, %v", tc.data, got.value, err, tc.want.value, tc.err)
+			}
+
+			var unmarshaled Date
+			err = unmarshaled.UnmarshalBinary(tc.data)
+			if !errors.Is(err, tc.err) || unmarshaled != tc.want {
+				t.Errorf("UnmarshalBinary(%x) = %v, %v, want %v, %v", tc.data, unmarshaled.value, err, tc.want.value, tc.err)
+			}
+		})
+	}
+}
+
+func TestDateFormat(t *testing.T) {
+	d := Date{value: fixed128.FromParts(0x40000004e2c, 0x9f3b7a5c1d2e4f68, false)}
+
+	tt := []struct {
+		layout string
+		want   string
+	}{
+		{LayoutCanonical, "@0000040000004e2c.9f3b7a5c1d2e4f68"},
+		{LayoutHex, "0000040000004e2c.9f3b7a5c1d2e4f68"},
+		{LayoutClock, "4e2c.9:f:3"},
+		{LayoutDecimal, "4398046531116.62200131176830918697"},
+		{LayoutQuad, "4e2c.21330323"},
+		{"%2D %2x %b", "2c 9f 0011101101111010010111000001110100101110010011110110100000000000"},
+		{"%o", "4763557227016456236640"},
+		{"%3f", "622"},
+		{"%d", "4398046531116"},
+		{"%06d", "4398046531116"},
+		{"day %% %y %", "day % %!y %!%"},
+		{"%73x|%99999999999999999999f", "%!x|%!f"},
+		{"", ""},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.layout, func(t *testing.T) {
+			if got := d.Format(tc.layout); got != tc.want {
+				t.Errorf("Format(%q) = %q, want %q", tc.layout, got, tc.want)
+			}
+		})
+	}
+
+	if got := d.Format(LayoutGlyphs); got != d.DateTimeGlyphs() {
+		t.Errorf("Format(LayoutGlyphs) = %q, want %q", got, d.DateTimeGlyphs())
+	}
+}
+
+func TestParse(t *testing.T) {
+	tt := []struct {
+		layout string
+		value  string
+		want   fixed128.Fixed128
+		offset int
+	}{
+		{LayoutCanonical, "@0000040000004e2c.9f3b7a5c1d2e4f68", fixed128.FromParts(0x40000004e2c, 0x9f3b7a5c1d2e4f68, false), -1},
+		{LayoutClock, "4e2c.9:F:3", fixed128.FromParts(0x4e2c, 0x9f3<<52, false), -1},
+		{LayoutDecimal, "4398046531116.62200131176830918697", fixed128.FromParts(0x40000004e2c, 0x9f3b7a5c1d2e4f68, false), -1},
+		{"%d.%1f", "20.5", fixed128.FromParts(20, 1<<63, false), -1},
+		{LayoutQuad, "4e2c.21330323", fixed128.FromParts(0x4e2c, 0x9f3b<<48, false), -1},
+		{"%2x%3b", "80001", fixed128.FromParts(0, 0x80<<56|1<<53, false), -1},
+		{"%%%D", "%000000000000000f", fixed128.FromParts(15, 0, false), -1},
+		{LayoutHex, "0000040000004e2c.9f3b7a5c1d2e4f6", fixed128.Zero, 32},
+		{LayoutCanonical, "0000040000004e2c.9f3b7a5c1d2e4f68", fixed128.Zero, 0},
+		{LayoutClock, "4e2c.9-f:3", fixed128.Zero, 6},
+		{LayoutClock, "4e2c.9:f:3 ", fixed128.Zero, 10},
+		{LayoutQuad, "4e2c.21340323", fixed128.Zero, 8},
+		{"%d", "", fixed128.Zero, 0},
+		{"%d", "99999999999999999999", fixed128.Zero, 0},
+		{"%y", "", fixed128.Zero, 0},
+		{"%%%999999999x", "%", fixed128.Zero, 3}, // within the layout
+		{LayoutGlyphs, "", fixed128.Zero, 0},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.layout+" "+tc.value, func(t *testing.T) {
+			got, err := Parse(tc.layout, tc.value)
+			if tc.offset < 0 {
+				if err != nil {
+					t.Fatalf("Parse() error = %v", err)
+				}
+				if got.value != tc.want {
+					t.Errorf("Parse() = %v, want %v", got.value, tc.want)
+				}
+				return
+			}
+
+			var perr *ParseError
+			if !errors.As(err, &perr) || !errors.Is(err, ErrInvalidBinaryTimeFormat) {
+				t.Fatalf("Parse() error = %v, want a ParseError", err)
+			}
+			if perr.Offset != tc.offset {
+				t.Errorf("Parse() error offset = %d, want %d: %v", perr.Offset, tc.offset, err)
+			}
+		})
+	}
+}
+
+func TestValidateLayout(t *testing.T) {
+	tt := []struct {
+		layout string
+		offset int // -1 if valid
+	}{
+		{LayoutCanonical, -1},
+		{LayoutClock, -1},
+		{LayoutGlyphs, -1},
+		{"%72b%%", -1},
+		{"", -1},
+		{"%73b", 1},
+		{"day %999999999x", 5},
+		{"%99999999999999999999f", 1},
+		{"%D.%y", 4},
+		{"%D %", 3},
+		{"%2%", 2},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.layout, func(t *testing.T) {
+			err := ValidateLayout(tc.layout)
+			if tc.offset < 0 {
+				if err != nil {
+					t.Fatalf("ValidateLayout() error = %v", err)
+				}
+				return
+			}
+
+			var perr *ParseError
+			if !errors.As(err, &perr) {
+				t.Fatalf("ValidateLayout() error = %v, want a ParseError", err)
+			}
+			if perr.Offset != tc.offset {
+				t.Errorf("ValidateLayout() error offset = %d, want %d: %v", perr.Offset, tc.offset, err)
+			}
+		})
+	}
+}
+
+func FuzzFormatParseRoundTrip(f *testing.F) {
+	f.Add(uint64(0), uint64(0))
+	f.Add(uint64(0x40000004e2c), uint64(0x9f3b7a5c1d2e4f68))
+	f.Add(^uint64(0), ^uint64(0))
+
+	f.Fuzz(func(t *testing.T, hi, lo uint64) {
+		d := Date{value: fixed128.FromParts(hi, lo, false)}
+		for _, layout := range []string{LayoutCanonical, LayoutHex, LayoutDecimal, "%d/%b"} {
+			s := d.Format(layout)
+			if got, err := Parse(layout, s); err != nil || got != d {
+				t.Fatalf("Parse(%q, %q) = %v, %v, want %v", layout, s, got.value, err, d.value)
+			}
+		}
+
+		// The glyphs only hold the bytes either side of the point
+		want := Date{value: fixed128.FromParts(hi&0xffff, lo&(0xffff<<48), false)}
+		if got, err := Parse(LayoutGlyphs, d.Format(LayoutGlyphs)); err != nil || got != want {
+			t.Fatalf("Parse(LayoutGlyphs) = %v, %v, want %v", got.value, err, want.value)
+		}
+	})
+}
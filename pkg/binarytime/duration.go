@@ -1,23 +1,140 @@
 package binarytime
 
 import (
+	"encoding"
+	"math"
 	"time"
 
 	"github.com/seannyphoenix/binarytime/pkg/fixed128"
 )
 
+// Duration is a signed span of binary time, measured in days.
 type Duration struct {
 	value fixed128.Fixed128
 }
 
+var (
+	_ encoding.BinaryMarshaler   = (*Duration)(nil)
+	_ encoding.BinaryUnmarshaler = (*Duration)(nil)
+)
+
 func FromDuration(d time.Duration) Duration {
 	return FromNanos(d.Nanoseconds())
 }
 
 func FromNanos(nanos int64) Duration {
-	v, err := fixed128.ByDivision(nanos, dayNs)
+	v, err := fixed128.ByDivisionRounded(nanos, dayNs, conversionRounding)
 	if err != nil {
 		return Duration{}
 	}
 	return Duration{value: v}
 }
+
+// MarshalBinary encodes the Duration using the signed binary layout
+// of fixed128, so negative durations survive a round trip.
+func (d Duration) MarshalBinary() ([]byte, error) {
+	return d.value.MarshalBinary()
+}
+
+func (d *Duration) UnmarshalBinary(data []byte) error {
+	value, err := fixed128.FromBytes(data)
+	if err != nil {
+		return err
+	}
+	d.value = value
+	return nil
+}
+
+// Fixed128 returns the underlying Fixed128 value of the Duration, in days.
+func (d Duration) Fixed128() fixed128.Fixed128 {
+	return d.value
+}
+
+// Nanoseconds returns the Duration as an integer nanosecond count, rounded
+// to nearest. Durations beyond the range of an int64, about
+// 292 years, saturate to math.MaxInt64 or math.MinInt64.
+func (d Duration) Nanoseconds() int64 {
+	ns, err := d.value.MulInt64Rounded(dayNs, conversionRounding)
+	if err != nil {
+		if d.value.Sign() {
+			return math.MinInt64
+		}
+		return math.MaxInt64
+	}
+	return ns
+}
+
+// ToDuration converts the Duration to a time.Duration,
+// saturating in the same way as Nanoseconds.
+func (d Duration) ToDuration() time.Duration {
+	return time.Duration(d.Nanoseconds())
+}
+
+// Add returns the sum d+other, saturating at the bounds of Fixed128.
+func (d Duration) Add(other Duration) Duration {
+	return Duration{value: d.value.AddSaturating(other.value)}
+}
+
+// Sub returns the difference d-other, saturating at the bounds of Fixed128.
+func (d Duration) Sub(other Duration) Duration {
+	return Duration{value: d.value.SubSaturating(other.value)}
+}
+
+// Mul returns the Duration multiplied by n, saturating at the bounds of Fixed128.
+func (d Duration) Mul(n int64) Duration {
+	return Duration{value: d.value.MulSaturating(fixed128.MustByDivision(n, 1))}
+}
+
+// Div returns the Duration divided by n, truncated toward zero.
+// Like integer division, it panics if n is zero.
+func (d Duration) Div(n int64) Duration {
+	v, err := d.value.Quo(fixed128.MustByDivision(n, 1))
+	if err != nil {
+		panic(err)
+	}
+	return Duration{value: v}
+}
+
+// Abs returns the absolute value of the Duration.
+func (d Duration) Abs() Duration {
+	return Duration{value: d.value.Abs()}
+}
+
+// Neg returns the Duration with its sign flipped.
+func (d Duration) Neg() Duration {
+	return Duration{value: d.value.Negate()}
+}
+
+// Cmp compares two Durations, returning -1, 0 or 1.
+func (d Duration) Cmp(other Duration) int {
+	return d.value.Cmp(other.value)
+}
+
+// Equals checks if two Durations are equal.
+func (d Duration) Equals(other Duration) bool {
+	return d.Cmp(other) == 0
+}
+
+// Truncate returns the Duration rounded toward zero to a multiple
+// of the granularity.
+func (d Duration) Truncate(g Granularity) Duration {
+	v, _ := g.round(d.value, fixed128.RoundTruncate)
+	return Duration{value: v}
+}
+
+// Round returns the Duration rounded to the nearest multiple of the
+// granularity, with halfway values rounded away from zero.
+// If rounding would overflow, the Duration is truncated instead.
+func (d Duration) Round(g Granularity) Duration {
+	v, err := g.round(d.value, fixed128.RoundHalfAwayFromZero)
+	if err != nil {
+		return d.Truncate(g)
+	}
+	return Duration{value: v}
+}
+
+// String returns the signed hexadecimal form of the Duration in days,
+// such as "-0000000000000001.8000000000000000" for minus a day and a half.
+func (d Duration) String() string {
+	return d.value.String()
+}
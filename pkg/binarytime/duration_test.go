@@ -0,0 +1,146 @@
+package binarytime
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/seannyphoenix/binarytime/pkg/fixed128"
+)
+
+func TestDurationBinaryRoundTrip(t *testing.T) {
+	tt := []time.Duration{0, time.Nanosecond, -time.Nanosecond, 8 * time.Hour, -36 * time.Hour}
+
+	for _, td := range tt {
+		d := FromDuration(td)
+		b, err := d.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary() returned error: %v", err)
+		}
+
+		var got Duration
+		if err := got.UnmarshalBinary(b); err != nil {
+			t.Fatalf("UnmarshalBinary(%x) returned error: %v", b, err)
+		}
+		if got != d {
+			t.Errorf("UnmarshalBinary(MarshalBinary(%v)) = %v, want %v", td, got.value, d.value)
+		}
+	}
+}
+
+func TestDurationToDuration(t *testing.T) {
+	tt := []time.Duration{0, time.Nanosecond, -time.Nanosecond, 8 * time.Hour, -36 * time.Hour, math.MaxInt64, math.MinInt64}
+
+	for _, td := range tt {
+		if got := FromDuration(td).ToDuration(); got != td {
+			t.Errorf("FromDuration(%v).ToDuration() = %v", td, got)
+		}
+	}
+}
+
+func TestDurationSaturates(t *testing.T) {
+	huge := FromDuration(math.MaxInt64).Mul(2)
+	if got := huge.Nanoseconds(); got != math.MaxInt64 {
+		t.Errorf("Nanoseconds() = %d, want %d", got, int64(math.MaxInt64))
+	}
+	if got := huge.Neg().ToDuration(); got != math.MinInt64 {
+		t.Errorf("ToDuration() = %d, want %d", got, int64(math.MinInt64))
+	}
+
+	top := Duration{value: fixed128.Max}
+	if got := top.Add(top); got != top {
+		t.Errorf("Add() = %v, want %v", got, top)
+	}
+	if got := top.Neg().Sub(top); got != top.Neg() {
+		t.Errorf("Sub() = %v, want %v", got, top.Neg())
+	}
+	if got := top.Mul(-3); got != top.Neg() {
+		t.Errorf("Mul() = %v, want %v", got, top.Neg())
+	}
+}
+
+func TestDurationArithmetic(t *testing.T) {
+	day := FromDuration(24 * time.Hour)
+	hour := FromDuration(time.Hour)
+
+	tt := []struct {
+		name string
+		got  Duration
+		want time.Duration
+	}{
+		{"add", day.Add(hour), 25 * time.Hour},
+		{"sub", hour.Sub(day), -23 * time.Hour},
+		{"mul", hour.Mul(-6), -6 * time.Hour},
+		{"div", day.Div(-8), -3 * time.Hour},
+		{"abs", hour.Neg().Abs(), time.Hour},
+		{"neg", day.Neg(), -24 * time.Hour},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.got.ToDuration(); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDurationCmp(t *testing.T) {
+	a, b := FromDuration(time.Second), FromDuration(time.Minute)
+
+	if a.Cmp(b) != -1 || b.Cmp(a) != 1 || a.Cmp(a) != 0 {
+		t.Errorf("Cmp(%v, %v) is inconsistent", a, b)
+	}
+	if !FromNanos(0).Equals(FromNanos(0).Neg()) {
+		t.Errorf("zero does not equal negative zero")
+	}
+	if a.Equals(b) {
+		t.Errorf("%v equals %v", a, b)
+	}
+}
+
+func TestDurationRound(t *testing.T) {
+	// Three and a half hexhours and its negation, in sixteenths of a day
+	d := Duration{value: fixed128.FromParts(0, 0x38<<56, false)}
+
+	tt := []struct {
+		name string
+		got  Duration
+		want Duration
+	}{
+		{"truncate", d.Truncate(GranularityHexHour), Duration{value: fixed128.FromParts(0, 3<<60, false)}},
+		{"round", d.Round(GranularityHexHour), Duration{value: fixed128.FromParts(0, 4<<60, false)}},
+		{"truncate negative", d.Neg().Truncate(GranularityHexHour), Duration{value: fixed128.FromParts(0, 3<<60, true)}},
+		{"round negative", d.Neg().Round(GranularityHexHour), Duration{value: fixed128.FromParts(0, 4<<60, true)}},
+		{"round to days", d.Round(GranularityDay), Duration{}},
+		{"truncate to hexmonths", FromDuration(-40 * 24 * time.Hour).Truncate(GranularityHexMonth), FromDuration(-32 * 24 * time.Hour)},
+		{"round to hexmonths", FromDuration(-40 * 24 * time.Hour).Round(GranularityHexMonth), FromDuration(-48 * 24 * time.Hour)},
+		{"round to hexmonths below half", FromDuration(39 * 24 * time.Hour).Round(GranularityHexMonth), FromDuration(32 * 24 * time.Hour)},
+		{"round max", Duration{value: fixed128.Max}.Round(GranularityHexHour), Duration{value: fixed128.FromParts(^uint64(0), 0xf<<60, false)}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if !tc.got.Equals(tc.want) {
+				t.Errorf("got %v, want %v", tc.got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDurationString(t *testing.T) {
+	tt := []struct {
+		d    Duration
+		want string
+	}{
+		{FromDuration(0), "0000000000000000.0000000000000000"},
+		{FromDuration(36 * time.Hour), "0000000000000001.8000000000000000"},
+		{FromDuration(-6 * time.Hour), "-0000000000000000.4000000000000000"},
+	}
+
+	for _, tc := range tt {
+		if got := tc.d.String(); got != tc.want {
+			t.Errorf("String() = %q, want %q", got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package binarytime
+
+import (
+	"fmt"
+
+	"github.com/seannyphoenix/binarytime/pkg/fixed128"
+)
+
+// Granularity is the precision of a Date or Duration, given as the number of
+// fractional bits of a day that are kept. Positive values subdivide the day,
+// zero keeps whole days and negative values keep multiples of 2^-g days.
+// Every fourth level lines up with a hex digit of the canonical form. The
+// named levels below the day run out with the SI prefixes at the
+// quectobit, so the hex digits between it and the tick go unnamed.
+type Granularity int
+
+const (
+	GranularityEon           Granularity = -60 // 2^60 days, the leading hex digit
+	GranularityEra           Granularity = -32 // 2^32 days, about 11.8 million years
+	GranularityHexMillennium Granularity = -20 // 2^20 days, about 2871 years
+	GranularityHexCentury    Granularity = -16 // 2^16 days, about 179 years
+	GranularityHexDecade     Granularity = -12 // 2^12 days, about 11.2 years
+	GranularityHexYear       Granularity = -8  // 256 days
+	GranularityHexMonth      Granularity = -4  // 16 days
+	GranularityHexWeek       Granularity = -2  // 4 days
+	GranularityDay           Granularity = 0
+	GranularityHexHour       Granularity = 4  // 1/16 day, 90 minutes
+	GranularityHexMinute     Granularity = 8  // 1/256 day, about 5.6 minutes
+	GranularityHexSecond     Granularity = 12 // 1/4096 day, about 21 seconds
+	GranularityMinibit       Granularity = 16 // about 1.3 seconds
+	GranularityMicrobit      Granularity = 20 // about 82 milliseconds
+	GranularityNanobit       Granularity = 24 // about 5.1 milliseconds
+	GranularityPicobit       Granularity = 28 // about 322 microseconds
+	GranularityFemtobit      Granularity = 32 // about 20 microseconds
+	GranularityAttobit       Granularity = 36 // about 1.3 microseconds
+	GranularityZeptobit      Granularity = 40 // about 79 nanoseconds
+	GranularityYoctobit      Granularity = 44 // about 4.9 nanoseconds
+	GranularityRontobit      Granularity = 48 // about 307 picoseconds
+	GranularityQuectobit     Granularity = 52 // about 19 picoseconds
+	GranularityTick          Granularity = 64 // 2^-64 day, the finest step
+)
+
+var granularityNames = map[Granularity]string{
+	GranularityEon:           "eon",
+	GranularityEra:           "era",
+	GranularityHexMillennium: "hexmillennium",
+	GranularityHexCentury:    "hexcentury",
+	GranularityHexDecade:     "hexdecade",
+	GranularityHexYear:       "hexyear",
+	GranularityHexMonth:      "hexmonth",
+	GranularityHexWeek:       "hexweek",
+	GranularityDay:           "day",
+	GranularityHexHour:       "hexhour",
+	GranularityHexMinute:     "hexminute",
+	GranularityHexSecond:     "hexsecond",
+	GranularityMinibit:       "minibit",
+	GranularityMicrobit:      "microbit",
+	GranularityNanobit:       "nanobit",
+	GranularityPicobit:       "picobit",
+	GranularityFemtobit:      "femtobit",
+	GranularityAttobit:       "attobit",
+	GranularityZeptobit:      "zeptobit",
+	GranularityYoctobit:      "yoctobit",
+	GranularityRontobit:      "rontobit",
+	GranularityQuectobit:     "quectobit",
+	GranularityTick:          "tick",
+}
+
+// String returns the name of the level, or "2^-56 day" and "2^-60 day"
+// for the unnamed hex digits between GranularityQuectobit and GranularityTick.
+func (g Granularity) String() string {
+	if name, ok := granularityNames[g]; ok {
+		return name
+	}
+	if g > GranularityQuectobit && g < GranularityTick && g%4 == 0 {
+		return fmt.Sprintf("2^-%d day", int(g))
+	}
+	return fmt.Sprintf("Granularity(%d)", int(g))
+}
+
+// clamp limits g to the levels a Fixed128 can express,
+// from GranularityEon to GranularityTick.
+func (g Granularity) clamp() Granularity {
+	return min(max(g, GranularityEon), GranularityTick)
+}
+
+// round rounds v to a multiple of 2^-g days according to mode.
+// It returns an error if the result overflows.
+func (g Granularity) round(v fixed128.Fixed128, mode fixed128.RoundingMode) (fixed128.Fixed128, error) {
+	g = g.clamp()
+	if g >= 0 {
+		return v.Round(uint(g), mode)
+	}
+
+	// The remainder is exact, so it can be compared with half a unit
+	shift := uint(-g)
+	unit, _ := fixed128.One.Lsh(shift)
+	r, _ := v.Rem(unit)
+	t, _ := v.Sub(r)
+	if r.IsZero() {
+		return t, nil
+	}
+
+	twice, _ := r.Abs().Lsh(1)
+	half := twice.Cmp(unit)
+	whole, _, _ := t.Abs().Rsh(shift).Parts()
+	odd := whole&1 == 1
+
+	var up bool
+	switch mode {
+	case fixed128.RoundFloor:
+		up = v.Sign()
+	case fixed128.RoundCeil:
+		up = !v.Sign()
+	case fixed128.RoundHalfEven:
+		up = half > 0 || (half == 0 && odd)
+	case fixed128.RoundHalfAwayFromZero:
+		up = half >= 0
+	}
+	if !up {
+		return t, nil
+	}
+	if v.Sign() {
+		unit = unit.Negate()
+	}
+	return t.Add(unit)
+}
+
+// Truncate returns the Date rounded down to a multiple of the granularity.
+func (d Date) Truncate(g Granularity) Date {
+	v, _ := g.round(d.value, fixed128.RoundFloor)
+	return Date{value: v}
+}
+
+// Round returns the Date rounded to the nearest multiple of the granularity,
+// with halfway values rounded up. If rounding up would overflow, the Date
+// is truncated instead.
+func (d Date) Round(g Granularity) Date {
+	v, err := g.round(d.value, fixed128.RoundHalfAwayFromZero)
+	if err != nil {
+		return d.Truncate(g)
+	}
+	return Date{value: v}
+}
+
+// HexGranular returns the canonical hex form of the Date truncated to the
+// granularity, keeping only the hex digits down to that level. Whole days
+// are always 16 digits wide when g is zero or finer, and the period only
+// appears when fractional digits follow it. A level between two hex digits
+// keeps the digit that contains it.
+func (d Date) HexGranular(g Granularity) string {
+	g = g.clamp()
+	hi, lo, _ := d.Truncate(g).value.Parts()
+	s := fmt.Sprintf("%016x.%016x", hi, lo)
+
+	if g <= 0 {
+		return s[:16+int(g)/4]
+	}
+	return s[:17+(int(g)+3)/4]
+}
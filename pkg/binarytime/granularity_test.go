@@ -0,0 +1,79 @@
+package binarytime
+
+import (
+	"testing"
+
+	"github.com/seannyphoenix/binarytime/pkg/fixed128"
+)
+
+func TestGranularity(t *testing.T) {
+	d := Date{value: fixed128.FromParts(0x0000040000004e2c, 0x9f3b7a5c1d2e4f68, false)}
+
+	tt := []struct {
+		g        Granularity
+		hex      string
+		truncate fixed128.Fixed128
+		round    fixed128.Fixed128
+	}{
+		{GranularityEon, "0", fixed128.FromParts(0x0, 0x0, false), fixed128.FromParts(0x0, 0x0, false)},
+		{GranularityEra, "00000400", fixed128.FromParts(0x40000000000, 0x0, false), fixed128.FromParts(0x40000000000, 0x0, false)},
+		{GranularityHexMillennium, "00000400000", fixed128.FromParts(0x40000000000, 0x0, false), fixed128.FromParts(0x40000000000, 0x0, false)},
+		{GranularityHexCentury, "000004000000", fixed128.FromParts(0x40000000000, 0x0, false), fixed128.FromParts(0x40000000000, 0x0, false)},
+		{GranularityHexDecade, "0000040000004", fixed128.FromParts(0x40000004000, 0x0, false), fixed128.FromParts(0x40000005000, 0x0, false)},
+		{GranularityHexYear, "0000040000004e", fixed128.FromParts(0x40000004e00, 0x0, false), fixed128.FromParts(0x40000004e00, 0x0, false)},
+		{GranularityHexMonth, "0000040000004e2", fixed128.FromParts(0x40000004e20, 0x0, false), fixed128.FromParts(0x40000004e30, 0x0, false)},
+		{GranularityHexWeek, "0000040000004e2c", fixed128.FromParts(0x40000004e2c, 0x0, false), fixed128.FromParts(0x40000004e2c, 0x0, false)},
+		{GranularityDay, "0000040000004e2c", fixed128.FromParts(0x40000004e2c, 0x0, false), fixed128.FromParts(0x40000004e2d, 0x0, false)},
+		{GranularityHexHour, "0000040000004e2c.9", fixed128.FromParts(0x40000004e2c, 0x9000000000000000, false), fixed128.FromParts(0x40000004e2c, 0xa000000000000000, false)},
+		{GranularityHexMinute, "0000040000004e2c.9f", fixed128.FromParts(0x40000004e2c, 0x9f00000000000000, false), fixed128.FromParts(0x40000004e2c, 0x9f00000000000000, false)},
+		{GranularityHexSecond, "0000040000004e2c.9f3", fixed128.FromParts(0x40000004e2c, 0x9f30000000000000, false), fixed128.FromParts(0x40000004e2c, 0x9f40000000000000, false)},
+		{GranularityMinibit, "0000040000004e2c.9f3b", fixed128.FromParts(0x40000004e2c, 0x9f3b000000000000, false), fixed128.FromParts(0x40000004e2c, 0x9f3b000000000000, false)},
+		{GranularityMicrobit, "0000040000004e2c.9f3b7", fixed128.FromParts(0x40000004e2c, 0x9f3b700000000000, false), fixed128.FromParts(0x40000004e2c, 0x9f3b800000000000, false)},
+		{GranularityNanobit, "0000040000004e2c.9f3b7a", fixed128.FromParts(0x40000004e2c, 0x9f3b7a0000000000, false), fixed128.FromParts(0x40000004e2c, 0x9f3b7a0000000000, false)},
+		{GranularityPicobit, "0000040000004e2c.9f3b7a5", fixed128.FromParts(0x40000004e2c, 0x9f3b7a5000000000, false), fixed128.FromParts(0x40000004e2c, 0x9f3b7a6000000000, false)},
+		{GranularityFemtobit, "0000040000004e2c.9f3b7a5c", fixed128.FromParts(0x40000004e2c, 0x9f3b7a5c00000000, false), fixed128.FromParts(0x40000004e2c, 0x9f3b7a5c00000000, false)},
+		{GranularityAttobit, "0000040000004e2c.9f3b7a5c1", fixed128.FromParts(0x40000004e2c, 0x9f3b7a5c10000000, false), fixed128.FromParts(0x40000004e2c, 0x9f3b7a5c20000000, false)},
+		{GranularityZeptobit, "0000040000004e2c.9f3b7a5c1d", fixed128.FromParts(0x40000004e2c, 0x9f3b7a5c1d000000, false), fixed128.FromParts(0x40000004e2c, 0x9f3b7a5c1d000000, false)},
+		{GranularityYoctobit, "0000040000004e2c.9f3b7a5c1d2", fixed128.FromParts(0x40000004e2c, 0x9f3b7a5c1d200000, false), fixed128.FromParts(0x40000004e2c, 0x9f3b7a5c1d300000, false)},
+		{GranularityRontobit, "0000040000004e2c.9f3b7a5c1d2e", fixed128.FromParts(0x40000004e2c, 0x9f3b7a5c1d2e0000, false), fixed128.FromParts(0x40000004e2c, 0x9f3b7a5c1d2e0000, false)},
+		{GranularityQuectobit, "0000040000004e2c.9f3b7a5c1d2e4", fixed128.FromParts(0x40000004e2c, 0x9f3b7a5c1d2e4000, false), fixed128.FromParts(0x40000004e2c, 0x9f3b7a5c1d2e5000, false)},
+		{GranularityTick, "0000040000004e2c.9f3b7a5c1d2e4f68", fixed128.FromParts(0x40000004e2c, 0x9f3b7a5c1d2e4f68, false), fixed128.FromParts(0x40000004e2c, 0x9f3b7a5c1d2e4f68, false)},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.g.String(), func(t *testing.T) {
+			if got := d.HexGranular(tc.g); got != tc.hex {
+				t.Errorf("HexGranular() = %q, want %q", got, tc.hex)
+			}
+			if got := d.Truncate(tc.g).value; got != tc.truncate {
+				t.Errorf("Truncate() = %v, want %v", got, tc.truncate)
+			}
+			if got := d.Round(tc.g).value; got != tc.round {
+				t.Errorf("Round() = %v, want %v", got, tc.round)
+			}
+		})
+	}
+}
+
+func TestGranularityEdges(t *testing.T) {
+	latest := Date{value: fixed128.Max}
+	if got := latest.Round(GranularityDay); got != latest.Truncate(GranularityDay) {
+		t.Errorf("Round() of the latest date = %v, want it truncated", got.value)
+	}
+
+	// Levels beyond the representable range are clamped
+	d := DateFromUnixNanos(0)
+	if got, want := d.HexGranular(Granularity(100)), d.HexGranular(GranularityTick); got != want {
+		t.Errorf("HexGranular(100) = %q, want %q", got, want)
+	}
+	if got, want := d.HexGranular(Granularity(-100)), d.HexGranular(GranularityEon); got != want {
+		t.Errorf("HexGranular(-100) = %q, want %q", got, want)
+	}
+
+	if got := Granularity(3).String(); got != "Granularity(3)" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := Granularity(56).String(); got != "2^-56 day" {
+		t.Errorf("String() = %q", got)
+	}
+}
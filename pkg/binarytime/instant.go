@@ -0,0 +1,122 @@
+package binarytime
+
+import "time"
+
+// Instant is a reading of the current binary time that, like time.Time,
+// also carries a reading of the monotonic clock. Differences between two
+// Instants from the same process use the monotonic readings, so they are
+// immune to the wall clock being set or slewed, while a Date only has the
+// wall clock to go on.
+//
+// Monotonic readings mean nothing outside the process that took them and
+// are lost when an Instant is turned into a Date, serialized or sent to
+// another process. Instants without one fall back to their wall clock
+// readings, so intervals between processes or machines are only as good
+// as the agreement of their wall clocks.
+type Instant struct {
+	t time.Time
+}
+
+// NowInstant returns the current Instant.
+func NowInstant() Instant {
+	return Instant{t: time.Now()}
+}
+
+// InstantFromTime returns the Instant of t,
+// keeping its monotonic reading if it has one.
+func InstantFromTime(t time.Time) Instant {
+	return Instant{t: t}
+}
+
+// Date returns the wall clock reading of the Instant as a Date.
+func (i Instant) Date() Date {
+	return DateFromTime(i.t)
+}
+
+// Time returns the Instant as a time.Time, monotonic reading included.
+func (i Instant) Time() time.Time {
+	return i.t
+}
+
+// Monotonic reports whether the Instant carries a monotonic clock reading.
+func (i Instant) Monotonic() bool {
+	return i.t != i.t.Round(0)
+}
+
+// Add returns the Instant d later, moving both readings.
+func (i Instant) Add(d Duration) Instant {
+	return Instant{t: i.t.Add(d.ToDuration())}
+}
+
+// Sub returns the Duration i-other, measured by the monotonic clock if both
+// Instants have a reading, to the nearest tick of a nanosecond count.
+// Like time.Time.Sub, it saturates at about 292 years.
+func (i Instant) Sub(other Instant) Duration {
+	return FromDuration(i.t.Sub(other.t))
+}
+
+// Since returns the Duration elapsed since i, measured by the monotonic
+// clock if i has a reading.
+func Since(i Instant) Duration {
+	return FromDuration(time.Since(i.t))
+}
+
+// Until returns the Duration until i, measured by the monotonic
+// clock if i has a reading.
+func Until(i Instant) Duration {
+	return FromDuration(time.Until(i.t))
+}
+
+// Stopwatch measures elapsed binary time by the monotonic clock,
+// across any number of starts and stops. The zero Stopwatch is stopped
+// with nothing elapsed. It is not safe for concurrent use.
+type Stopwatch struct {
+	running bool
+	start   Instant
+	elapsed Duration
+}
+
+// StartStopwatch returns a running Stopwatch.
+func StartStopwatch() *Stopwatch {
+	s := &Stopwatch{}
+	s.Start()
+	return s
+}
+
+// Start starts or resumes the Stopwatch.
+// If it is already running, Start is a no-op.
+func (s *Stopwatch) Start() {
+	if !s.running {
+		s.running = true
+		s.start = NowInstant()
+	}
+}
+
+// Stop pauses the Stopwatch and returns the total elapsed.
+// If it is not running, Stop is a no-op.
+func (s *Stopwatch) Stop() Duration {
+	if s.running {
+		s.elapsed = s.elapsed.Add(Since(s.start))
+		s.running = false
+	}
+	return s.elapsed
+}
+
+// Reset stops the Stopwatch and clears the elapsed time.
+func (s *Stopwatch) Reset() {
+	*s = Stopwatch{}
+}
+
+// Elapsed returns the total time the Stopwatch has run,
+// including the current run if it is running.
+func (s *Stopwatch) Elapsed() Duration {
+	if s.running {
+		return s.elapsed.Add(Since(s.start))
+	}
+	return s.elapsed
+}
+
+// Running reports whether the Stopwatch is running.
+func (s *Stopwatch) Running() bool {
+	return s.running
+}
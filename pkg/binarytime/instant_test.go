@@ -0,0 +1,77 @@
+package binarytime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInstant(t *testing.T) {
+	now := time.Now()
+	i := InstantFromTime(now)
+	if !i.Monotonic() {
+		t.Fatalf("Monotonic() = false for time.Now()")
+	}
+	if got := i.Date(); got != DateFromTime(now) {
+		t.Errorf("Date() = %v, want %v", got.value, DateFromTime(now).value)
+	}
+
+	// Jumping the wall clock reading leaves monotonic differences alone
+	jumped := InstantFromTime(now.Add(time.Minute))
+	wall := InstantFromTime(now.Round(0).Add(-time.Hour))
+	if wall.Monotonic() {
+		t.Errorf("Monotonic() = true after Round(0)")
+	}
+	if got := jumped.Sub(i).ToDuration(); got != time.Minute {
+		t.Errorf("Sub() = %v, want 1m", got)
+	}
+	if got := i.Sub(wall).ToDuration(); got != time.Hour {
+		t.Errorf("Sub() of a wall reading = %v, want 1h", got)
+	}
+
+	later := i.Add(FromDuration(time.Second))
+	if !later.Monotonic() || later.Sub(i).ToDuration() != time.Second {
+		t.Errorf("Add() = %v, want a monotonic reading a second later", later.Time())
+	}
+
+	if got := Since(i); got.Cmp(Duration{}) < 0 {
+		t.Errorf("Since() = %v, want it non-negative", got)
+	}
+	if got := Until(later); got.Cmp(Duration{}) <= 0 || got.ToDuration() > time.Second {
+		t.Errorf("Until() = %v, want within the next second", got.ToDuration())
+	}
+}
+
+func TestStopwatch(t *testing.T) {
+	var s Stopwatch
+	if s.Running() || !s.Elapsed().value.IsZero() {
+		t.Fatalf("zero Stopwatch is running or has elapsed %v", s.Elapsed())
+	}
+
+	s.Start()
+	time.Sleep(2 * time.Millisecond)
+	first := s.Stop()
+	if s.Running() || first.ToDuration() < 2*time.Millisecond {
+		t.Errorf("Stop() = %v, want at least 2ms", first.ToDuration())
+	}
+	if got := s.Elapsed(); got != first {
+		t.Errorf("Elapsed() when stopped = %v, want %v", got, first)
+	}
+	if got := s.Stop(); got != first {
+		t.Errorf("Stop() when stopped = %v, want %v", got, first)
+	}
+
+	s.Start()
+	time.Sleep(2 * time.Millisecond)
+	if got := s.Elapsed(); got.Cmp(first) <= 0 || !s.Running() {
+		t.Errorf("Elapsed() when resumed = %v, want more than %v", got, first)
+	}
+
+	s.Reset()
+	if s.Running() || !s.Elapsed().value.IsZero() {
+		t.Errorf("Reset() left it running or with %v elapsed", s.Elapsed())
+	}
+
+	if s := StartStopwatch(); !s.Running() {
+		t.Errorf("StartStopwatch() is not running")
+	}
+}
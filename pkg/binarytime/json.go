@@ -0,0 +1,326 @@
+package binarytime
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/seannyphoenix/binarytime/pkg/fixed128"
+)
+
+// JSONFormat selects the wire form of a Date or Duration in JSON.
+// Whatever the format, UnmarshalJSON accepts all of them.
+type JSONFormat int
+
+const (
+	// JSONHex is the canonical form shared with the TypeScript and Swift
+	// clients: "0000040000004e2c.9f3b7a5c1d2e4f68". Durations carry a
+	// leading minus sign when negative.
+	JSONHex JSONFormat = iota
+	// JSONHexAt is the MarshalText form, JSONHex prefixed with '@'.
+	// Durations never use the prefix, so for them it is the same as JSONHex.
+	JSONHexAt
+	// JSONBase64 is the standard base64 encoding of the binary form:
+	// 16 bytes for a Date and the signed 17-byte layout for a Duration.
+	JSONBase64
+	// JSONDays is a JSON number of days, with the exact decimal expansion
+	// of the fraction. Most JSON decoders round it to a float64.
+	JSONDays
+	// JSONSidecar is an object pairing JSONHex with a familiar form:
+	// {"binaryTime":"…","time":"2006-01-02T15:04:05.999999999Z"} for a Date
+	// and {"binaryTime":"…","duration":"1h30m0s"} for a Duration.
+	JSONSidecar
+)
+
+var (
+	_ json.Marshaler   = Date{}
+	_ json.Unmarshaler = (*Date)(nil)
+	_ json.Marshaler   = Duration{}
+	_ json.Unmarshaler = (*Duration)(nil)
+
+	_ json.Marshaler   = DateHexAt{}
+	_ json.Unmarshaler = (*DateHexAt)(nil)
+	_ json.Marshaler   = DateBase64{}
+	_ json.Unmarshaler = (*DateBase64)(nil)
+	_ json.Marshaler   = DateDays{}
+	_ json.Unmarshaler = (*DateDays)(nil)
+	_ json.Marshaler   = DateSidecar{}
+	_ json.Unmarshaler = (*DateSidecar)(nil)
+	_ json.Marshaler   = DurationBase64{}
+	_ json.Unmarshaler = (*DurationBase64)(nil)
+	_ json.Marshaler   = DurationDays{}
+	_ json.Unmarshaler = (*DurationDays)(nil)
+	_ json.Marshaler   = DurationSidecar{}
+	_ json.Unmarshaler = (*DurationSidecar)(nil)
+)
+
+type jsonSidecar struct {
+	BinaryTime json.RawMessage `json:"binaryTime"`
+	Time       string          `json:"time,omitempty"`
+	Duration   string          `json:"duration,omitempty"`
+}
+
+// MarshalJSON encodes the Date as JSONHex. Convert it to DateHexAt,
+// DateBase64, DateDays or DateSidecar for the other formats.
+func (d Date) MarshalJSON() ([]byte, error) {
+	return d.MarshalJSONFormat(JSONHex)
+}
+
+// MarshalJSONFormat encodes the Date in the given format.
+func (d Date) MarshalJSONFormat(format JSONFormat) ([]byte, error) {
+	hi, lo, _ := d.value.Parts()
+	hex := fmt.Sprintf("%016x.%016x", hi, lo)
+
+	switch format {
+	case JSONHex:
+		return json.Marshal(hex)
+	case JSONHexAt:
+		return json.Marshal("@" + hex)
+	case JSONBase64:
+		return json.Marshal(d.value.Base64())
+	case JSONDays:
+		return []byte(d.value.Decimal(-1)), nil
+	case JSONSidecar:
+		return json.Marshal(jsonSidecar{
+			BinaryTime: json.RawMessage(`"` + hex + `"`),
+			Time:       d.Time().UTC().Format(time.RFC3339Nano),
+		})
+	default:
+		return nil, fmt.Errorf("%w: unknown JSON format %d", ErrInvalidBinaryTimeFormat, format)
+	}
+}
+
+// UnmarshalJSON accepts every JSONFormat. Strings are read as hex, with or
+// without '@' and in the shortened forms UnmarshalText allows, or failing
+// that as base64. A sidecar object without binaryTime falls back to its
+// RFC 3339 time. Like the standard library decoders, it leaves the Date
+// unchanged when given null.
+func (d *Date) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		return nil
+	case len(data) > 0 && data[0] == '"':
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidBinaryTimeFormat, data)
+		}
+		value, err := parseHexDate(s)
+		if err != nil {
+			var b64Err error
+			if value, b64Err = parseBase64(s); b64Err != nil {
+				return err
+			}
+		}
+		date, err := DateFromFixed128(value)
+		if err != nil {
+			return err
+		}
+		*d = date
+		return nil
+	case len(data) > 0 && data[0] == '{':
+		var obj jsonSidecar
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidBinaryTimeFormat, data)
+		}
+		if obj.BinaryTime != nil {
+			return d.UnmarshalJSON(obj.BinaryTime)
+		}
+		t, err := time.Parse(time.RFC3339Nano, obj.Time)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidBinaryTimeFormat, data)
+		}
+		*d = DateFromTime(t)
+		return nil
+	default:
+		value, err := parseDays(data)
+		if err != nil {
+			return err
+		}
+		if value.Sign() {
+			return fmt.Errorf("%w: %s is before the zero date", ErrInvalidBinaryTimeFormat, data)
+		}
+		d.value = value
+		return nil
+	}
+}
+
+// MarshalJSON encodes the Duration as JSONHex. Convert it to
+// DurationBase64, DurationDays or DurationSidecar for the other formats.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return d.MarshalJSONFormat(JSONHex)
+}
+
+// MarshalJSONFormat encodes the Duration in the given format.
+func (d Duration) MarshalJSONFormat(format JSONFormat) ([]byte, error) {
+	switch format {
+	case JSONHex, JSONHexAt:
+		return json.Marshal(d.value.String())
+	case JSONBase64:
+		b, _ := d.value.MarshalBinary()
+		return json.Marshal(base64.StdEncoding.EncodeToString(b))
+	case JSONDays:
+		return []byte(d.value.Decimal(-1)), nil
+	case JSONSidecar:
+		return json.Marshal(jsonSidecar{
+			BinaryTime: json.RawMessage(`"` + d.value.String() + `"`),
+			Duration:   d.ToDuration().String(),
+		})
+	default:
+		return nil, fmt.Errorf("%w: unknown JSON format %d", ErrInvalidBinaryTimeFormat, format)
+	}
+}
+
+// UnmarshalJSON accepts every JSONFormat. A sidecar object without
+// binaryTime falls back to its duration, in time.ParseDuration syntax.
+// Like the standard library decoders, it leaves the Duration unchanged
+// when given null.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		return nil
+	case len(data) > 0 && data[0] == '"':
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidBinaryTimeFormat, data)
+		}
+		value, err := fixed128.ParseHex(s)
+		if err != nil {
+			if value, err = parseBase64(s); err != nil {
+				return fmt.Errorf("%w: %q", ErrInvalidBinaryTimeFormat, s)
+			}
+		}
+		d.value = value
+		return nil
+	case len(data) > 0 && data[0] == '{':
+		var obj jsonSidecar
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidBinaryTimeFormat, data)
+		}
+		if obj.BinaryTime != nil {
+			return d.UnmarshalJSON(obj.BinaryTime)
+		}
+		td, err := time.ParseDuration(obj.Duration)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidBinaryTimeFormat, data)
+		}
+		*d = FromDuration(td)
+		return nil
+	default:
+		value, err := parseDays(data)
+		if err != nil {
+			return err
+		}
+		d.value = value
+		return nil
+	}
+}
+
+// DateHexAt is a Date encoded in JSON as JSONHexAt.
+// Like all the wrappers, it decodes every JSONFormat.
+type DateHexAt Date
+
+func (d DateHexAt) MarshalJSON() ([]byte, error) {
+	return Date(d).MarshalJSONFormat(JSONHexAt)
+}
+
+func (d *DateHexAt) UnmarshalJSON(data []byte) error {
+	return (*Date)(d).UnmarshalJSON(data)
+}
+
+// DateBase64 is a Date encoded in JSON as JSONBase64.
+type DateBase64 Date
+
+func (d DateBase64) MarshalJSON() ([]byte, error) {
+	return Date(d).MarshalJSONFormat(JSONBase64)
+}
+
+func (d *DateBase64) UnmarshalJSON(data []byte) error {
+	return (*Date)(d).UnmarshalJSON(data)
+}
+
+// DateDays is a Date encoded in JSON as JSONDays.
+type DateDays Date
+
+func (d DateDays) MarshalJSON() ([]byte, error) {
+	return Date(d).MarshalJSONFormat(JSONDays)
+}
+
+func (d *DateDays) UnmarshalJSON(data []byte) error {
+	return (*Date)(d).UnmarshalJSON(data)
+}
+
+// DateSidecar is a Date encoded in JSON as JSONSidecar.
+type DateSidecar Date
+
+func (d DateSidecar) MarshalJSON() ([]byte, error) {
+	return Date(d).MarshalJSONFormat(JSONSidecar)
+}
+
+func (d *DateSidecar) UnmarshalJSON(data []byte) error {
+	return (*Date)(d).UnmarshalJSON(data)
+}
+
+// DurationBase64 is a Duration encoded in JSON as JSONBase64.
+type DurationBase64 Duration
+
+func (d DurationBase64) MarshalJSON() ([]byte, error) {
+	return Duration(d).MarshalJSONFormat(JSONBase64)
+}
+
+func (d *DurationBase64) UnmarshalJSON(data []byte) error {
+	return (*Duration)(d).UnmarshalJSON(data)
+}
+
+// DurationDays is a Duration encoded in JSON as JSONDays.
+type DurationDays Duration
+
+func (d DurationDays) MarshalJSON() ([]byte, error) {
+	return Duration(d).MarshalJSONFormat(JSONDays)
+}
+
+func (d *DurationDays) UnmarshalJSON(data []byte) error {
+	return (*Duration)(d).UnmarshalJSON(data)
+}
+
+// DurationSidecar is a Duration encoded in JSON as JSONSidecar.
+type DurationSidecar Duration
+
+func (d DurationSidecar) MarshalJSON() ([]byte, error) {
+	return Duration(d).MarshalJSONFormat(JSONSidecar)
+}
+
+func (d *DurationSidecar) UnmarshalJSON(data []byte) error {
+	return (*Duration)(d).UnmarshalJSON(data)
+}
+
+// parseBase64 decodes either base64 alphabet, accepting both binary layouts.
+func parseBase64(s string) (fixed128.Fixed128, error) {
+	if strings.ContainsAny(s, "-_") {
+		return fixed128.ParseBase64URL(s)
+	}
+	return fixed128.ParseBase64(s)
+}
+
+// parseDays reads a JSON number of days, exponents included,
+// rounding to the nearest tick.
+func parseDays(data []byte) (fixed128.Fixed128, error) {
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fixed128.Zero, fmt.Errorf("%w: %s", ErrInvalidBinaryTimeFormat, data)
+	}
+	r, ok := new(big.Rat).SetString(n.String())
+	if !ok {
+		return fixed128.Zero, fmt.Errorf("%w: %s", ErrInvalidBinaryTimeFormat, data)
+	}
+	value, err := fixed128.FromBigRat(r)
+	if err != nil {
+		return fixed128.Zero, fmt.Errorf("%w: %s: %w", ErrInvalidBinaryTimeFormat, data, err)
+	}
+	return value, nil
+}
@@ -0,0 +1,201 @@
+package binarytime
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/seannyphoenix/binarytime/pkg/fixed128"
+)
+
+func TestDateMarshalJSON(t *testing.T) {
+	// Noon on 2 January 1970
+	d := DateFromUnixNanos(int64(36 * time.Hour))
+
+	tt := []struct {
+		format JSONFormat
+		want   string
+	}{
+		{JSONHex, `"0000040000000001.8000000000000000"`},
+		{JSONHexAt, `"@0000040000000001.8000000000000000"`},
+		{JSONBase64, `"AAAEAAAAAAGAAAAAAAAAAA=="`},
+		{JSONDays, `4398046511105.5`},
+		{JSONSidecar, `{"binaryTime":"0000040000000001.8000000000000000","time":"1970-01-02T12:00:00Z"}`},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.want, func(t *testing.T) {
+			got, err := d.MarshalJSONFormat(tc.format)
+			if err != nil {
+				t.Fatalf("MarshalJSONFormat() error = %v", err)
+			}
+			if string(got) != tc.want {
+				t.Errorf("MarshalJSONFormat() = %s, want %s", got, tc.want)
+			}
+
+			var back Date
+			if err := json.Unmarshal(got, &back); err != nil || back != d {
+				t.Errorf("Unmarshal(%s) = %v, %v, want %v", got, back.value, err, d.value)
+			}
+		})
+	}
+}
+
+func TestDurationMarshalJSON(t *testing.T) {
+	d := FromDuration(-36 * time.Hour)
+
+	tt := []struct {
+		format JSONFormat
+		want   string
+	}{
+		{JSONHex, `"-0000000000000001.8000000000000000"`},
+		{JSONHexAt, `"-0000000000000001.8000000000000000"`},
+		{JSONBase64, `"gQAAAAAAAAABgAAAAAAAAAA="`},
+		{JSONDays, `-1.5`},
+		{JSONSidecar, `{"binaryTime":"-0000000000000001.8000000000000000","duration":"-36h0m0s"}`},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.want, func(t *testing.T) {
+			got, err := d.MarshalJSONFormat(tc.format)
+			if err != nil {
+				t.Fatalf("MarshalJSONFormat() error = %v", err)
+			}
+			if string(got) != tc.want {
+				t.Errorf("MarshalJSONFormat() = %s, want %s", got, tc.want)
+			}
+
+			var back Duration
+			if err := json.Unmarshal(got, &back); err != nil || back != d {
+				t.Errorf("Unmarshal(%s) = %v, %v, want %v", got, back, err, d)
+			}
+		})
+	}
+}
+
+func TestJSONWrappers(t *testing.T) {
+	type event struct {
+		At      DateHexAt       `json:"at"`
+		Day     DateDays        `json:"day"`
+		For     DurationDays    `json:"for"`
+		Blob    DurationBase64  `json:"blob"`
+		Default Date            `json:"default"`
+		Human   DurationSidecar `json:"human"`
+	}
+	epoch := DateFromUnixNanos(0)
+	quarter := FromDuration(6 * time.Hour)
+	in := event{DateHexAt(epoch), DateDays(epoch), DurationDays(quarter), DurationBase64(quarter.Neg()), epoch, DurationSidecar(quarter)}
+
+	got, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	want := `{"at":"@0000040000000000.0000000000000000","day":4398046511104,"for":0.25,` +
+		`"blob":"gQAAAAAAAAAAQAAAAAAAAAA=","default":"0000040000000000.0000000000000000",` +
+		`"human":{"binaryTime":"0000000000000000.4000000000000000","duration":"6h0m0s"}}`
+	if string(got) != want {
+		t.Errorf("Marshal() = %s, want %s", got, want)
+	}
+
+	var out event
+	if err := json.Unmarshal(got, &out); err != nil || out != in {
+		t.Errorf("Unmarshal(%s) = %+v, %v, want %+v", got, out, err, in)
+	}
+
+	var sidecar DateSidecar
+	if err := json.Unmarshal([]byte(`"AAAEAAAAAAAAAAAAAAAAAA=="`), &sidecar); err != nil || Date(sidecar) != epoch {
+		t.Errorf("Unmarshal() into DateSidecar = %v, %v, want %v", Date(sidecar).value, err, epoch.value)
+	}
+	if b, _ := json.Marshal(DateBase64(epoch)); string(b) != `"AAAEAAAAAAAAAAAAAAAAAA=="` {
+		t.Errorf("Marshal(DateBase64) = %s", b)
+	}
+}
+
+func TestDateUnmarshalJSON(t *testing.T) {
+	epoch := DateFromUnixNanos(0)
+
+	tt := []struct {
+		json string
+		want Date
+		err  error
+	}{
+		{`"40000000000"`, epoch, nil},
+		{`"@40000000000.0"`, epoch, nil},
+		{`"AAAEAAAAAAAAAAAAAAAAAA=="`, epoch, nil},
+		{`"AQAABAAAAAAAAAAAAAAAAAA="`, epoch, nil},
+		{`4.398046511104e12`, epoch, nil},
+		{`{"time":"1970-01-01T01:00:00+01:00"}`, epoch, nil},
+		{`{"binaryTime":"@40000000000","time":"2000-01-01T00:00:00Z"}`, epoch, nil},
+		{`null`, Date{}, nil},
+		{`"not a time"`, Date{}, ErrInvalidBinaryTimeFormat},
+		{`-1`, Date{}, ErrInvalidBinaryTimeFormat},
+		{`"gQAABAAAAAAAAAAAAAAAAAA="`, Date{}, ErrInvalidBinaryTimeFormat},
+		{`1e30`, Date{}, fixed128.ErrorOutOfRange},
+		{`{"time":"yesterday"}`, Date{}, ErrInvalidBinaryTimeFormat},
+		{`true`, Date{}, ErrInvalidBinaryTimeFormat},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.json, func(t *testing.T) {
+			var got Date
+			err := json.Unmarshal([]byte(tc.json), &got)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("Unmarshal() error = %v, want %v", err, tc.err)
+			}
+			if got != tc.want {
+				t.Errorf("Unmarshal() = %v, want %v", got.value, tc.want.value)
+			}
+		})
+	}
+}
+
+func TestDurationUnmarshalJSON(t *testing.T) {
+	tt := []struct {
+		json string
+		want Duration
+		err  error
+	}{
+		{`"-1.8"`, FromDuration(-36 * time.Hour), nil},
+		{`"0.4"`, FromDuration(6 * time.Hour), nil},
+		{`0.25`, FromDuration(6 * time.Hour), nil},
+		{`{"duration":"1h30m"}`, FromDuration(90 * time.Minute), nil},
+		{`"@1.0"`, Duration{}, ErrInvalidBinaryTimeFormat},
+		{`{"duration":"soon"}`, Duration{}, ErrInvalidBinaryTimeFormat},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.json, func(t *testing.T) {
+			var got Duration
+			err := json.Unmarshal([]byte(tc.json), &got)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("Unmarshal() error = %v, want %v", err, tc.err)
+			}
+			if got != tc.want {
+				t.Errorf("Unmarshal() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func FuzzDateJSONRoundTrip(f *testing.F) {
+	f.Add(uint64(1<<42), uint64(1<<63), 0)
+	f.Add(^uint64(0), ^uint64(0), 3)
+
+	f.Fuzz(func(t *testing.T, hi, lo uint64, format int) {
+		d := Date{value: fixed128.FromParts(hi, lo, false)}
+		f := JSONFormat(format % int(JSONSidecar+1))
+		if f < 0 {
+			f = -f
+		}
+
+		data, err := d.MarshalJSONFormat(f)
+		if err != nil {
+			t.Fatalf("MarshalJSONFormat(%d) error = %v", f, err)
+		}
+		var got Date
+		if err := json.Unmarshal(data, &got); err != nil || got != d {
+			t.Fatalf("Unmarshal(%s) = %v, %v, want %v", data, got.value, err, d.value)
+		}
+	})
+}
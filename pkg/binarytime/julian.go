@@ -0,0 +1,90 @@
+package binarytime
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/seannyphoenix/binarytime/pkg/fixed128"
+)
+
+// Binary days and Julian days are both counts of 86400-second days, so
+// the conversions are exact shifts: the zero date is Julian Day
+// -4398044070516.5, and the last Date is still within the range of a
+// Fixed128.
+var (
+	julianDayOffset         = fixed128.FromParts(4398044070516, 1<<63, false) // 2^42 - 2440587.5
+	modifiedJulianDayOffset = fixed128.FromParts(4398046470517, 0, false)     // 2^42 - 40587
+)
+
+// JulianDay returns the Julian Day of the Date, the days since noon
+// Universal Time on 1 January 4713 BC in the proleptic Julian calendar.
+func (d Date) JulianDay() fixed128.Fixed128 {
+	jd, _ := d.value.Sub(julianDayOffset)
+	return jd
+}
+
+// DateFromJulianDay returns the Date of a Julian Day, or an ErrOutOfRange
+// error if it is before the zero date or after the last Date.
+func DateFromJulianDay(jd fixed128.Fixed128) (Date, error) {
+	return dateFromShifted(jd, julianDayOffset, "Julian Day")
+}
+
+// JulianDayNumber returns the integer Julian Day Number of the Julian
+// day, running from noon to noon, that contains the Date.
+func (d Date) JulianDayNumber() *big.Int {
+	jd := d.JulianDay().ScaledBigInt()
+	return jd.Rsh(jd, 64)
+}
+
+// ModifiedJulianDay returns the Modified Julian Day of the Date,
+// the days since midnight Universal Time on 17 November 1858.
+func (d Date) ModifiedJulianDay() fixed128.Fixed128 {
+	mjd, _ := d.value.Sub(modifiedJulianDayOffset)
+	return mjd
+}
+
+// DateFromModifiedJulianDay returns the Date of a Modified Julian Day, or an
+// ErrOutOfRange error if it is before the zero date or after the last Date.
+func DateFromModifiedJulianDay(mjd fixed128.Fixed128) (Date, error) {
+	return dateFromShifted(mjd, modifiedJulianDayOffset, "Modified Julian Day")
+}
+
+var (
+	j2000      = big.NewRat(2451545, 1)
+	julianYear = big.NewRat(36525, 100)
+	j2000Epoch = big.NewRat(2000, 1)
+)
+
+// JulianYear returns the Date as an exact Julian epoch year, with a fraction,
+// counting years of 365.25 days from J2000.0, noon on 1 January 2000.
+// It is the year astronomers use, and stays within a day of the Gregorian
+// year for thousands of years either side of 2000.
+func (d Date) JulianYear() *big.Rat {
+	y := new(big.Rat).Sub(d.JulianDay().BigRat(), j2000)
+	y.Quo(y, julianYear)
+	return y.Add(y, j2000Epoch)
+}
+
+// DateFromJulianYear returns the Date of a Julian epoch year, rounded to
+// the nearest tick, or an ErrOutOfRange error if it is before the zero
+// date or after the last Date.
+func DateFromJulianYear(year *big.Rat) (Date, error) {
+	jd := new(big.Rat).Sub(year, j2000Epoch)
+	jd.Mul(jd, julianYear)
+	jd.Add(jd, j2000)
+
+	days, err := fixed128.FromBigRat(jd.Add(jd, julianDayOffset.BigRat()))
+	if err != nil || days.Sign() {
+		return Date{}, fmt.Errorf("%w: Julian year %s", ErrOutOfRange, year.FloatString(6))
+	}
+	return Date{value: days}, nil
+}
+
+// dateFromShifted returns the Date v+offset days, checking its range.
+func dateFromShifted(v, offset fixed128.Fixed128, name string) (Date, error) {
+	days, err := v.Add(offset)
+	if err != nil || days.Sign() {
+		return Date{}, fmt.Errorf("%w: %s %s", ErrOutOfRange, name, v.Decimal(-1))
+	}
+	return Date{value: days}, nil
+}
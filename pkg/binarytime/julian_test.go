@@ -0,0 +1,71 @@
+package binarytime
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/seannyphoenix/binarytime/pkg/fixed128"
+)
+
+func TestJulianDay(t *testing.T) {
+	tt := []struct {
+		name  string
+		date  Date
+		jd    string
+		jdn   string
+		mjd   string
+		jyear string
+	}{
+		{"unix epoch", DateFromUnix(0, 0), "2440587.5", "2440587", "40587", "1970.000000000000"},
+		{"J2000.0", DateFromTime(time.Date(2000, time.January, 1, 12, 0, 0, 0, time.UTC)), "2451545", "2451545", "51544.5", "2000.000000000000"},
+		{"MJD epoch", DateFromTime(time.Date(1858, time.November, 17, 0, 0, 0, 0, time.UTC)), "2400000.5", "2400000", "0", "1858.878850102669"},
+		{"zero date", Date{}, "-4398044070516.5", "-4398044070517", "-4398046470517", "-12041193132.269678302533"},
+		{"latest date", Date{value: fixed128.FromParts(^uint64(0), 0, false)}, "18446739675665481098.5", "18446739675665481098", "18446739675663081098", "50504420741036988.510609172"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.date.JulianDay().Decimal(-1); got != tc.jd {
+				t.Errorf("JulianDay() = %s, want %s", got, tc.jd)
+			}
+			if got := tc.date.JulianDayNumber().String(); got != tc.jdn {
+				t.Errorf("JulianDayNumber() = %s, want %s", got, tc.jdn)
+			}
+			if got := tc.date.ModifiedJulianDay().Decimal(-1); got != tc.mjd {
+				t.Errorf("ModifiedJulianDay() = %s, want %s", got, tc.mjd)
+			}
+			year := tc.date.JulianYear()
+			if got := year.FloatString(len(tc.jyear) - len(year.FloatString(0)) - 1); got != tc.jyear {
+				t.Errorf("JulianYear() = %s, want %s", got, tc.jyear)
+			}
+
+			if got, err := DateFromJulianDay(tc.date.JulianDay()); err != nil || got != tc.date {
+				t.Errorf("DateFromJulianDay() = %v, %v, want %v", got.value, err, tc.date.value)
+			}
+			if got, err := DateFromModifiedJulianDay(tc.date.ModifiedJulianDay()); err != nil || got != tc.date {
+				t.Errorf("DateFromModifiedJulianDay() = %v, %v, want %v", got.value, err, tc.date.value)
+			}
+			if got, err := DateFromJulianYear(year); err != nil || got != tc.date {
+				t.Errorf("DateFromJulianYear() = %v, %v, want %v", got.value, err, tc.date.value)
+			}
+		})
+	}
+}
+
+func TestJulianDayRange(t *testing.T) {
+	before, _ := fixed128.ParseDecimal("-4398044070517")
+	if _, err := DateFromJulianDay(before); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("DateFromJulianDay() before the zero date error = %v, want ErrOutOfRange", err)
+	}
+	if _, err := DateFromModifiedJulianDay(fixed128.Max); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("DateFromModifiedJulianDay() after the latest date error = %v, want ErrOutOfRange", err)
+	}
+	if _, err := DateFromJulianYear(big.NewRat(-13_000_000_000, 1)); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("DateFromJulianYear() before the zero date error = %v, want ErrOutOfRange", err)
+	}
+	if _, err := DateFromJulianYear(big.NewRat(1e17, 1)); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("DateFromJulianYear() after the latest date error = %v, want ErrOutOfRange", err)
+	}
+}
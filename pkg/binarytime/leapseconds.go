@@ -0,0 +1,111 @@
+package binarytime
+
+import "time"
+
+// Dates converted from time.Time follow Unix time, which ignores leap
+// seconds, so their binary days are UTC days and some of them last 86401
+// SI seconds. TAI-based Dates instead count days of exactly 86400 SI
+// seconds, and run ahead of UTC-based Dates by TAI−UTC.
+
+// leapSecond is a change in TAI−UTC, in seconds,
+// taking effect at a midnight UTC.
+type leapSecond struct {
+	at     Date
+	offset int
+}
+
+// leapSeconds is every change in TAI−UTC since UTC took its present form
+// in 1972, from the IERS list. It needs a new entry whenever IERS Bulletin C
+// announces a leap second; none has been since the one at the end of 2016.
+var leapSeconds = func() []leapSecond {
+	table := []struct {
+		year   int
+		month  time.Month
+		offset int
+	}{
+		{1972, time.January, 10},
+		{1972, time.July, 11},
+		{1973, time.January, 12},
+		{1974, time.January, 13},
+		{1975, time.January, 14},
+		{1976, time.January, 15},
+		{1977, time.January, 16},
+		{1978, time.January, 17},
+		{1979, time.January, 18},
+		{1980, time.January, 19},
+		{1981, time.July, 20},
+		{1982, time.July, 21},
+		{1983, time.July, 22},
+		{1985, time.July, 23},
+		{1988, time.January, 24},
+		{1990, time.January, 25},
+		{1991, time.January, 26},
+		{1992, time.July, 27},
+		{1993, time.July, 28},
+		{1994, time.July, 29},
+		{1996, time.January, 30},
+		{1997, time.July, 31},
+		{1999, time.January, 32},
+		{2006, time.January, 33},
+		{2009, time.January, 34},
+		{2012, time.July, 35},
+		{2015, time.July, 36},
+		{2017, time.January, 37},
+	}
+
+	leaps := make([]leapSecond, len(table))
+	for i, e := range table {
+		at := DateFromTime(time.Date(e.year, e.month, 1, 0, 0, 0, 0, time.UTC))
+		leaps[i] = leapSecond{at: at, offset: e.offset}
+	}
+	return leaps
+}()
+
+func leapOffset(seconds int) Duration {
+	return FromDuration(time.Duration(seconds) * time.Second)
+}
+
+// TAIOffset returns TAI−UTC at the UTC-based Date d. Before 1972 it is
+// taken to be the 10 seconds it was then set to, rather than following
+// the fractional offsets of the years before.
+func TAIOffset(d Date) Duration {
+	offset := leapSeconds[0].offset
+	for _, leap := range leapSeconds {
+		if d.Before(leap.at) {
+			break
+		}
+		offset = leap.offset
+	}
+	return leapOffset(offset)
+}
+
+// UTCToTAI converts a UTC-based Date to a TAI-based one.
+func UTCToTAI(d Date) Date {
+	return d.Add(TAIOffset(d))
+}
+
+// TAIToUTC converts a TAI-based Date to a UTC-based one, the reverse of
+// UTCToTAI. UTC-based Dates cannot express the leap seconds themselves,
+// so TAI instants during one return the midnight UTC that follows it.
+func TAIToUTC(d Date) Date {
+	for i := len(leapSeconds) - 1; i >= 0; i-- {
+		leap := leapSeconds[i]
+		if !d.Before(leap.at.Add(leapOffset(leap.offset))) {
+			return d.Add(leapOffset(leap.offset).Neg())
+		}
+		if i > 0 && !d.Before(leap.at.Add(leapOffset(leapSeconds[i-1].offset))) {
+			return leap.at
+		}
+	}
+	return d.Add(leapOffset(leapSeconds[0].offset).Neg())
+}
+
+// DateFromTimeTAI returns the TAI-based Date of t.
+func DateFromTimeTAI(t time.Time) Date {
+	return UTCToTAI(DateFromTime(t))
+}
+
+// TimeTAI returns the time.Time of a TAI-based Date.
+func (d Date) TimeTAI() time.Time {
+	return TAIToUTC(d).Time()
+}
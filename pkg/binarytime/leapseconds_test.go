@@ -0,0 +1,69 @@
+package binarytime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTAIOffset(t *testing.T) {
+	tt := []struct {
+		t      time.Time
+		offset int
+	}{
+		{time.Date(1960, time.January, 1, 0, 0, 0, 0, time.UTC), 10},
+		{time.Date(1972, time.June, 30, 23, 59, 59, 0, time.UTC), 10},
+		{time.Date(1972, time.July, 1, 0, 0, 0, 0, time.UTC), 11},
+		{time.Date(2008, time.December, 31, 23, 59, 59, 999_000_000, time.UTC), 33},
+		{time.Date(2009, time.January, 1, 0, 0, 0, 0, time.UTC), 34},
+		{time.Date(2015, time.June, 30, 23, 59, 59, 0, time.UTC), 35},
+		{time.Date(2015, time.July, 1, 0, 0, 0, 0, time.UTC), 36},
+		{time.Date(2016, time.December, 31, 23, 59, 59, 0, time.UTC), 36},
+		{time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC), 37},
+		{time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC), 37},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.t.Format(time.RFC3339Nano), func(t *testing.T) {
+			d := DateFromTime(tc.t)
+			want := FromDuration(time.Duration(tc.offset) * time.Second)
+			if got := TAIOffset(d); got != want {
+				t.Errorf("TAIOffset() = %v, want %d seconds", got, tc.offset)
+			}
+			if got := UTCToTAI(d).Sub(d); got != want {
+				t.Errorf("UTCToTAI() is %v ahead, want %d seconds", got, tc.offset)
+			}
+			if got := TAIToUTC(UTCToTAI(d)); got != d {
+				t.Errorf("TAIToUTC(UTCToTAI()) = %v, want %v", got.value, d.value)
+			}
+			if got := DateFromTimeTAI(tc.t).TimeTAI(); !got.Equal(tc.t) {
+				t.Errorf("TimeTAI() = %v, want %v", got, tc.t)
+			}
+		})
+	}
+}
+
+func TestLeapSecondInsertion(t *testing.T) {
+	// One UTC second across the end of 2016 lasts two TAI seconds
+	before := DateFromTime(time.Date(2016, time.December, 31, 23, 59, 59, 0, time.UTC))
+	after := DateFromTime(time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC))
+
+	if got := UTCToTAI(after).Sub(UTCToTAI(before)).ToDuration(); got != 2*time.Second {
+		t.Errorf("TAI span across the leap second = %v, want 2s", got)
+	}
+
+	// TAI instants during the leap second hold at midnight UTC
+	leap := UTCToTAI(before).Add(FromDuration(1500 * time.Millisecond))
+	if got := TAIToUTC(leap); got != after {
+		t.Errorf("TAIToUTC() during the leap second = %v, want %v", got.Time(), after.Time())
+	}
+	if got := TAIToUTC(UTCToTAI(after)); got != after {
+		t.Errorf("TAIToUTC() at the end of the leap second = %v, want %v", got.Time(), after.Time())
+	}
+
+	// Every leap second in the table is a positive one
+	for i := 1; i < len(leapSeconds); i++ {
+		if leapSeconds[i].offset != leapSeconds[i-1].offset+1 || !leapSeconds[i-1].at.Before(leapSeconds[i].at) {
+			t.Errorf("leap second %d is out of order", i)
+		}
+	}
+}
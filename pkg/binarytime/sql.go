@@ -0,0 +1,114 @@
+package binarytime
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"time"
+
+	"github.com/seannyphoenix/binarytime/pkg/fixed128"
+)
+
+// Date and Duration work with any database/sql driver, and with pgx, which
+// uses the same interfaces. A Date is stored as its 16-byte big-endian
+// binary form, for BYTEA or BLOB columns, whose byte order is time order so
+// that they index and sort correctly. Convert to DateText or DateTimestamp
+// to store a column as text or timestamps instead. Whatever the column,
+// Scan reads all three forms.
+
+var (
+	_ sql.Scanner   = (*Date)(nil)
+	_ driver.Valuer = Date{}
+	_ sql.Scanner   = (*DateText)(nil)
+	_ driver.Valuer = DateText{}
+	_ sql.Scanner   = (*DateTimestamp)(nil)
+	_ driver.Valuer = DateTimestamp{}
+	_ sql.Scanner   = (*Duration)(nil)
+	_ driver.Valuer = Duration{}
+)
+
+// Scan implements sql.Scanner. A []byte that fixed128.IsBinary is decoded
+// as a binary form, and any other []byte or a string is read as text in
+// any form UnmarshalText accepts. A time.Time is converted with DateFromTime.
+// NULL is an error; scan into a *Date or sql.Null[Date] for nullable columns.
+func (d *Date) Scan(src any) error {
+	switch src := src.(type) {
+	case []byte:
+		if fixed128.IsBinary(src) {
+			value, err := fixed128.FromBytes(src)
+			if err != nil {
+				return err
+			}
+			date, err := DateFromFixed128(value)
+			if err != nil {
+				return err
+			}
+			*d = date
+			return nil
+		}
+		return d.UnmarshalText(src)
+	case string:
+		return d.UnmarshalText([]byte(src))
+	case time.Time:
+		*d = DateFromTime(src)
+		return nil
+	case nil:
+		return fmt.Errorf("%w: cannot scan NULL into Date", ErrInvalidBinaryTimeFormat)
+	default:
+		return fmt.Errorf("%w: cannot scan %T into Date", ErrInvalidBinaryTimeFormat, src)
+	}
+}
+
+// Value implements driver.Valuer, storing the 16-byte binary form.
+func (d Date) Value() (driver.Value, error) {
+	return d.Bytes(), nil
+}
+
+// DateText is a Date stored in TEXT columns, in the MarshalText form
+// "@0000040000004e2c.9f3b7a5c1d2e4f68", which also sorts correctly.
+type DateText Date
+
+// Scan implements sql.Scanner, reading anything Date.Scan does.
+func (d *DateText) Scan(src any) error {
+	return (*Date)(d).Scan(src)
+}
+
+// Value implements driver.Valuer, storing the MarshalText form.
+func (d DateText) Value() (driver.Value, error) {
+	text, err := Date(d).MarshalText()
+	return string(text), err
+}
+
+// DateTimestamp is a Date stored in TIMESTAMP columns as a time.Time in
+// UTC, rounded to the nanosecond or coarser depending on the database.
+type DateTimestamp Date
+
+// Scan implements sql.Scanner, reading anything Date.Scan does.
+func (d *DateTimestamp) Scan(src any) error {
+	return (*Date)(d).Scan(src)
+}
+
+// Value implements driver.Valuer, storing a time.Time in UTC.
+func (d DateTimestamp) Value() (driver.Value, error) {
+	return Date(d).Time().UTC(), nil
+}
+
+// Scan implements sql.Scanner, reading the binary and text forms of
+// fixed128.Fixed128.Scan. NULL is an error.
+func (d *Duration) Scan(src any) error {
+	if src == nil {
+		return fmt.Errorf("%w: cannot scan NULL into Duration", ErrInvalidBinaryTimeFormat)
+	}
+	var value fixed128.Fixed128
+	if err := value.Scan(src); err != nil {
+		return err
+	}
+	d.value = value
+	return nil
+}
+
+// Value implements driver.Valuer, storing the signed 17-byte binary form
+// so that negative durations survive a round trip.
+func (d Duration) Value() (driver.Value, error) {
+	return d.MarshalBinary()
+}
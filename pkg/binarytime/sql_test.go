@@ -0,0 +1,257 @@
+package binarytime
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/seannyphoenix/binarytime/pkg/fixed128"
+)
+
+// stubDriver is an in-memory database with one untyped column per DSN,
+// storing values as given, the way SQLite does. "INSERT" adds a row,
+// "SELECT" reads them all back in order and "DELETE" clears them.
+type stubDriver struct {
+	mu     sync.Mutex
+	tables map[string][]driver.Value
+}
+
+var stub = &stubDriver{tables: map[string][]driver.Value{}}
+
+func init() {
+	sql.Register("binarytime-stub", stub)
+}
+
+func (s *stubDriver) Open(dsn string) (driver.Conn, error) {
+	return &stubConn{driver: s, dsn: dsn}, nil
+}
+
+type stubConn struct {
+	driver *stubDriver
+	dsn    string
+}
+
+func (c *stubConn) Prepare(query string) (driver.Stmt, error) {
+	return &stubStmt{conn: c, query: query}, nil
+}
+
+func (c *stubConn) Close() error              { return nil }
+func (c *stubConn) Begin() (driver.Tx, error) { return nil, errors.New("stub: no transactions") }
+
+type stubStmt struct {
+	conn  *stubConn
+	query string
+}
+
+func (s *stubStmt) Close() error { return nil }
+
+func (s *stubStmt) NumInput() int {
+	if s.query == "INSERT" {
+		return 1
+	}
+	return 0
+}
+
+func (s *stubStmt) Exec(args []driver.Value) (driver.Result, error) {
+	d := s.conn.driver
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	switch s.query {
+	case "INSERT":
+		d.tables[s.conn.dsn] = append(d.tables[s.conn.dsn], args[0])
+	case "DELETE":
+		delete(d.tables, s.conn.dsn)
+	default:
+		return nil, errors.New("stub: unknown statement " + s.query)
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (s *stubStmt) Query(args []driver.Value) (driver.Rows, error) {
+	d := s.conn.driver
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return &stubRows{values: append([]driver.Value(nil), d.tables[s.conn.dsn]...)}, nil
+}
+
+type stubRows struct {
+	values []driver.Value
+}
+
+func (r *stubRows) Columns() []string { return []string{"v"} }
+func (r *stubRows) Close() error      { return nil }
+
+func (r *stubRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	dest[0], r.values = r.values[0], r.values[1:]
+	return nil
+}
+
+func openStub(t *testing.T) *sql.DB {
+	db, err := sql.Open("binarytime-stub", t.Name())
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	t.Cleanup(func() {
+		db.Exec("DELETE")
+		db.Close()
+	})
+	return db
+}
+
+func TestDateSQL(t *testing.T) {
+	d := Date{value: fixed128.FromParts(0x40000004e2c, 0x9f3b7a5c1d2e4f68, false)}
+	nanos := DateFromTime(d.Time())
+
+	tt := []struct {
+		name   string
+		arg    any // d, as the type stored
+		scan   func(*sql.Row) (Date, error)
+		stored driver.Value
+		want   Date
+	}{
+		{
+			"bytes", d,
+			func(row *sql.Row) (Date, error) {
+				var got Date
+				err := row.Scan(&got)
+				return got, err
+			},
+			d.Bytes(), d,
+		},
+		{
+			"text", DateText(d),
+			func(row *sql.Row) (Date, error) {
+				var got DateText
+				err := row.Scan(&got)
+				return Date(got), err
+			},
+			"@0000040000004e2c.9f3b7a5c1d2e4f68", d,
+		},
+		{
+			"timestamp", DateTimestamp(d),
+			func(row *sql.Row) (Date, error) {
+				var got DateTimestamp
+				err := row.Scan(&got)
+				return Date(got), err
+			},
+			d.Time().UTC(), nanos,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			db := openStub(t)
+			if _, err := db.Exec("INSERT", tc.arg); err != nil {
+				t.Fatalf("Exec() error = %v", err)
+			}
+
+			stored := stub.tables[t.Name()][0]
+			switch want := tc.stored.(type) {
+			case []byte:
+				if got, ok := stored.([]byte); !ok || string(got) != string(want) {
+					t.Errorf("stored %#v, want %#v", stored, want)
+				}
+			case time.Time:
+				if got, ok := stored.(time.Time); !ok || !got.Equal(want) {
+					t.Errorf("stored %#v, want %v", stored, want)
+				}
+			default:
+				if stored != want {
+					t.Errorf("stored %#v, want %#v", stored, want)
+				}
+			}
+
+			got, err := tc.scan(db.QueryRow("SELECT"))
+			if err != nil {
+				t.Fatalf("Scan() error = %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("Scan() = %v, want %v", got.value, tc.want.value)
+			}
+		})
+	}
+}
+
+func TestDateScan(t *testing.T) {
+	d := Date{value: fixed128.FromParts(0x4e2c, 1<<63, false)}
+
+	tt := []struct {
+		name string
+		src  any
+		want Date
+		err  bool
+	}{
+		{"blob", d.Bytes(), d, false},
+		{"version 1 blob", append([]byte{0x01}, d.Bytes()...), d, false},
+		{"negative blob", append([]byte{0x81}, d.Bytes()...), Date{}, true},
+		{"text", "@4e2c.8", d, false},
+		{"text bytes", []byte("0000000000004e2c.8000000000000000"), d, false},
+		{"16-byte text", []byte("@4e2c.8000000000"), d, false},
+		{"17-byte text", []byte("@04e2c.8000000000"), d, false},
+		{"bad text", "yesterday", Date{}, true},
+		{"timestamp", time.Unix(0, 0), DateFromUnixNanos(0), false},
+		{"null", nil, Date{}, true},
+		{"integer", int64(20012), Date{}, true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var got Date
+			err := got.Scan(tc.src)
+			if tc.err {
+				if err == nil {
+					t.Fatalf("Scan(%v) = %v, want an error", tc.src, got.value)
+				}
+				return
+			}
+			if err != nil || got != tc.want {
+				t.Errorf("Scan(%v) = %v, %v, want %v", tc.src, got.value, err, tc.want.value)
+			}
+		})
+	}
+
+	// Nullable columns go through sql.Null
+	db := openStub(t)
+	db.Exec("INSERT", nil)
+	var null sql.Null[Date]
+	if err := db.QueryRow("SELECT").Scan(&null); err != nil || null.Valid {
+		t.Errorf("Scan() of NULL = %v, %v, want an invalid sql.Null", null, err)
+	}
+}
+
+func TestDurationSQL(t *testing.T) {
+	db := openStub(t)
+	want := []Duration{FromDuration(-90 * time.Minute), {}, {value: fixed128.Max}}
+	for _, d := range want {
+		if _, err := db.Exec("INSERT", d); err != nil {
+			t.Fatalf("Exec() error = %v", err)
+		}
+	}
+	db.Exec("INSERT", "-0.1")
+
+	rows, err := db.Query("SELECT")
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	defer rows.Close()
+
+	want = append(want, Duration{value: fixed128.FromParts(0, 1<<60, true)})
+	for i := 0; rows.Next(); i++ {
+		var got Duration
+		if err := rows.Scan(&got); err != nil || got != want[i] {
+			t.Errorf("Scan() = %v, %v, want %v", got, err, want[i])
+		}
+	}
+
+	var d Duration
+	if err := d.Scan(nil); !errors.Is(err, ErrInvalidBinaryTimeFormat) {
+		t.Errorf("Scan(nil) error = %v, want ErrInvalidBinaryTimeFormat", err)
+	}
+}
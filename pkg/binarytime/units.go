@@ -0,0 +1,237 @@
+package binarytime
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/seannyphoenix/binarytime/pkg/fixed128"
+)
+
+// Unit names a power-of-two subdivision or multiple of a day.
+type Unit struct {
+	Name        string // singular, such as "hexhour"
+	Plural      string // such as "hexhours"
+	Granularity Granularity
+}
+
+// Units names every named Granularity level, from eons down to ticks.
+// Copy and rename it to configure HumanizeUnits and ParseHumanizedUnits.
+var Units = []Unit{
+	{"eon", "eons", GranularityEon},
+	{"era", "eras", GranularityEra},
+	{"hexmillennium", "hexmillennia", GranularityHexMillennium},
+	{"hexcentury", "hexcenturies", GranularityHexCentury},
+	{"hexdecade", "hexdecades", GranularityHexDecade},
+	{"hexyear", "hexyears", GranularityHexYear},
+	{"hexmonth", "hexmonths", GranularityHexMonth},
+	{"hexweek", "hexweeks", GranularityHexWeek},
+	{"day", "days", GranularityDay},
+	{"hexhour", "hexhours", GranularityHexHour},
+	{"hexminute", "hexminutes", GranularityHexMinute},
+	{"hexsecond", "hexseconds", GranularityHexSecond},
+	{"minibit", "minibits", GranularityMinibit},
+	{"microbit", "microbits", GranularityMicrobit},
+	{"nanobit", "nanobits", GranularityNanobit},
+	{"picobit", "picobits", GranularityPicobit},
+	{"femtobit", "femtobits", GranularityFemtobit},
+	{"attobit", "attobits", GranularityAttobit},
+	{"zeptobit", "zeptobits", GranularityZeptobit},
+	{"yoctobit", "yoctobits", GranularityYoctobit},
+	{"rontobit", "rontobits", GranularityRontobit},
+	{"quectobit", "quectobits", GranularityQuectobit},
+	{"tick", "ticks", GranularityTick},
+}
+
+// Component is a count of one Unit.
+type Component struct {
+	Unit  Unit
+	Count uint64
+}
+
+func (c Component) String() string {
+	if c.Count == 1 {
+		return "1 " + c.Unit.Name
+	}
+	return strconv.FormatUint(c.Count, 10) + " " + c.Unit.Plural
+}
+
+// value returns the length of the Component in days.
+func (c Component) value() (fixed128.Fixed128, error) {
+	g := c.Unit.Granularity.clamp()
+	unit := fixed128.One.Rsh(uint(max(g, 0)))
+	if g < 0 {
+		unit, _ = fixed128.One.Lsh(uint(-g))
+	}
+	return fixed128.FromParts(c.Count, 0, false).Mul(unit)
+}
+
+// Components breaks the Date into a count of each of Units,
+// coarsest first, so that each count is below the size of the next unit up.
+func (d Date) Components() []Component {
+	return components(d.value, Units)
+}
+
+// Humanize spells out the Duration in Units, such as
+// "3 hexhours 2 minibits", omitting units with a count of zero.
+// Negative durations begin with '-'.
+func (d Duration) Humanize() string {
+	return d.HumanizeUnits(Units)
+}
+
+// HumanizeUnits is like Humanize but with the given units, in any order.
+// Whatever is shorter than the finest unit is dropped, and a count that
+// doesn't fit in a uint64, which can only happen when the coarsest unit
+// is shorter than a day, is capped at math.MaxUint64.
+func (d Duration) HumanizeUnits(units []Unit) string {
+	if len(units) == 0 {
+		return ""
+	}
+
+	var parts []string
+	for _, c := range components(d.value, units) {
+		if c.Count != 0 {
+			parts = append(parts, c.String())
+		}
+	}
+	if len(parts) == 0 {
+		finest := slices.MaxFunc(units, func(a, b Unit) int {
+			return cmp.Compare(a.Granularity, b.Granularity)
+		})
+		return Component{Unit: finest}.String()
+	}
+
+	s := strings.Join(parts, " ")
+	if d.value.Sign() {
+		s = "-" + s
+	}
+	return s
+}
+
+// components counts each of units in the magnitude of v, coarsest first.
+func components(v fixed128.Fixed128, units []Unit) []Component {
+	units = slices.SortedFunc(slices.Values(units), func(a, b Unit) int {
+		return cmp.Compare(a.Granularity, b.Granularity)
+	})
+	hi, lo, _ := v.Parts()
+
+	cs := make([]Component, len(units))
+	for i, u := range units {
+		// Count the ticks from this unit up to the next coarser one
+		shift := uint(GranularityTick - u.Granularity.clamp())
+		width := uint(128)
+		if i > 0 {
+			width = uint(u.Granularity.clamp() - units[i-1].Granularity.clamp())
+		}
+		cs[i] = Component{Unit: u, Count: bitField(hi, lo, shift, width)}
+	}
+	return cs
+}
+
+// bitField returns width bits of the 128-bit number hi:lo starting at bit
+// shift, capped at math.MaxUint64.
+func bitField(hi, lo uint64, shift, width uint) uint64 {
+	switch {
+	case shift >= 128:
+		hi, lo = 0, 0
+	case shift >= 64:
+		hi, lo = 0, hi>>(shift-64)
+	case shift > 0:
+		hi, lo = hi>>shift, lo>>shift|hi<<(64-shift)
+	}
+
+	if width < 64 {
+		return lo & (1<<width - 1)
+	}
+	over := hi
+	if width < 128 {
+		over &= 1<<(width-64) - 1
+	}
+	if over != 0 {
+		return ^uint64(0)
+	}
+	return lo
+}
+
+// ParseHumanized parses the output of Humanize: an optional '-' and then
+// counts of Units, by their singular or plural names, in any order and
+// case. Repeated units add up.
+func ParseHumanized(s string) (Duration, error) {
+	return ParseHumanizedUnits(s, Units)
+}
+
+// ParseHumanizedUnits is like ParseHumanized but with the given units.
+// Errors are *ParseError values positioned within s.
+func ParseHumanizedUnits(s string, units []Unit) (Duration, error) {
+	fail := func(offset int, format string, args ...any) (Duration, error) {
+		return Duration{}, &ParseError{Text: s, Offset: offset, Msg: fmt.Sprintf(format, args...)}
+	}
+
+	names := make(map[string]Unit, 2*len(units))
+	for _, u := range units {
+		names[strings.ToLower(u.Name)] = u
+		names[strings.ToLower(u.Plural)] = u
+	}
+
+	// fields splits s at spaces, keeping the offset of each field
+	type field struct {
+		text   string
+		offset int
+	}
+	var fields []field
+	for i := 0; i < len(s); {
+		if s[i] == ' ' || s[i] == '\t' {
+			i++
+			continue
+		}
+		j := i
+		for j < len(s) && s[j] != ' ' && s[j] != '\t' {
+			j++
+		}
+		fields = append(fields, field{s[i:j], i})
+		i = j
+	}
+
+	var neg bool
+	if len(fields) > 0 && strings.HasPrefix(fields[0].text, "-") {
+		neg = true
+		fields[0].text = fields[0].text[1:]
+		fields[0].offset++
+		if fields[0].text == "" {
+			fields = fields[1:]
+		}
+	}
+	if len(fields) == 0 {
+		return fail(len(s), "expected a count")
+	}
+
+	total := fixed128.Zero
+	for i := 0; i < len(fields); i += 2 {
+		count, err := strconv.ParseUint(fields[i].text, 10, 64)
+		if err != nil {
+			return fail(fields[i].offset, "expected a count")
+		}
+		if i+1 == len(fields) {
+			return fail(len(s), "expected a unit")
+		}
+		u, ok := names[strings.ToLower(fields[i+1].text)]
+		if !ok {
+			return fail(fields[i+1].offset, "unknown unit %q", fields[i+1].text)
+		}
+
+		v, err := Component{Unit: u, Count: count}.value()
+		if err == nil {
+			total, err = total.Add(v)
+		}
+		if err != nil {
+			return fail(fields[i].offset, "too long")
+		}
+	}
+
+	if neg {
+		total = total.Negate()
+	}
+	return Duration{value: total}, nil
+}
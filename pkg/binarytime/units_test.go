@@ -0,0 +1,119 @@
+package binarytime
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/seannyphoenix/binarytime/pkg/fixed128"
+)
+
+func TestDateComponents(t *testing.T) {
+	d := Date{value: fixed128.FromParts(0x0000040000004e2c, 0x9f3b7a5c1d2e4f68, false)}
+	want := []uint64{0, 0x400, 0, 0, 4, 0xe, 2, 3, 0, 9, 0xf, 3, 0xb, 7, 0xa, 5, 0xc, 1, 0xd, 2, 0xe, 4, 0xf68}
+
+	got := d.Components()
+	if len(got) != len(want) {
+		t.Fatalf("Components() has %d units, want %d", len(got), len(want))
+	}
+	for i, c := range got {
+		if c.Unit != Units[i] || c.Count != want[i] {
+			t.Errorf("Components()[%d] = %v, want %d %s", i, c, want[i], Units[i].Plural)
+		}
+	}
+}
+
+func TestHumanize(t *testing.T) {
+	short := []Unit{
+		{"d", "d", GranularityDay},
+		{"hh", "hh", GranularityHexHour},
+		{"hm", "hm", GranularityHexMinute},
+	}
+
+	tt := []struct {
+		value fixed128.Fixed128
+		units []Unit
+		want  string
+	}{
+		{fixed128.FromParts(0, 3<<60|2<<48, false), Units, "3 hexhours 2 minibits"},
+		{fixed128.FromParts(0, 3<<60|2<<48, true), Units, "-3 hexhours 2 minibits"},
+		{fixed128.FromParts(0x101, 1<<60, false), Units, "1 hexyear 1 day 1 hexhour"},
+		{fixed128.FromParts(0, 1, false), Units, "1 tick"},
+		{fixed128.Zero, Units, "0 ticks"},
+		{fixed128.Max, Units, "15 eons 268435455 eras 4095 hexmillennia 15 hexcenturies 15 hexdecades 15 hexyears 15 hexmonths 3 hexweeks 3 days 15 hexhours 15 hexminutes 15 hexseconds 15 minibits 15 microbits 15 nanobits 15 picobits 15 femtobits 15 attobits 15 zeptobits 15 yoctobits 15 rontobits 15 quectobits 4095 ticks"},
+		{fixed128.FromParts(20, 0x12f<<52, false), short, "20 d 1 hh 2 hm"},
+		{fixed128.FromParts(0, 0x1, false), short, "0 hm"},
+		{fixed128.FromParts(0, 0x12f<<52, false), short[1:], "1 hh 2 hm"},
+		{fixed128.FromParts(1<<60, 0, false), []Unit{{"tick", "ticks", GranularityTick}}, "18446744073709551615 ticks"},
+		{fixed128.One, nil, ""},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.want, func(t *testing.T) {
+			if got := (Duration{value: tc.value}).HumanizeUnits(tc.units); got != tc.want {
+				t.Errorf("HumanizeUnits() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseHumanized(t *testing.T) {
+	tt := []struct {
+		s      string
+		want   fixed128.Fixed128
+		offset int
+	}{
+		{"3 hexhours 2 minibits", fixed128.FromParts(0, 3<<60|2<<48, false), -1},
+		{"-3 hexhours 2 minibits", fixed128.FromParts(0, 3<<60|2<<48, true), -1},
+		{"- 1 Day", fixed128.FromParts(1, 0, true), -1},
+		{"  2 minibits\t3 HEXHOURS ", fixed128.FromParts(0, 3<<60|2<<48, false), -1},
+		{"17 hexhours 1 hexhour", fixed128.FromParts(1, 2<<60, false), -1},
+		{"1 hexyear", fixed128.FromParts(256, 0, false), -1},
+		{"0 ticks", fixed128.Zero, -1},
+		{"", fixed128.Zero, 0},
+		{"-", fixed128.Zero, 1},
+		{"3", fixed128.Zero, 1},
+		{"3 hexhours 2", fixed128.Zero, 12},
+		{"three hexhours", fixed128.Zero, 0},
+		{"3 hours", fixed128.Zero, 2},
+		{"3 hexhours, 2 minibits", fixed128.Zero, 2},
+		{"16 eons", fixed128.Zero, 0},
+		{"15 eons 268435456 eras", fixed128.Zero, 8},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.s, func(t *testing.T) {
+			got, err := ParseHumanized(tc.s)
+			if tc.offset < 0 {
+				if err != nil {
+					t.Fatalf("ParseHumanized() error = %v", err)
+				}
+				if got.value != tc.want {
+					t.Errorf("ParseHumanized() = %v, want %v", got.value, tc.want)
+				}
+				return
+			}
+
+			var perr *ParseError
+			if !errors.As(err, &perr) || !errors.Is(err, ErrInvalidBinaryTimeFormat) {
+				t.Fatalf("ParseHumanized() error = %v, want a ParseError", err)
+			}
+			if perr.Offset != tc.offset {
+				t.Errorf("ParseHumanized() error offset = %d, want %d: %v", perr.Offset, tc.offset, err)
+			}
+		})
+	}
+}
+
+func FuzzHumanizeRoundTrip(f *testing.F) {
+	f.Add(uint64(0), uint64(0), false)
+	f.Add(uint64(0x40000004e2c), uint64(0x9f3b7a5c1d2e4f68), true)
+	f.Add(^uint64(0), ^uint64(0), false)
+
+	f.Fuzz(func(t *testing.T, hi, lo uint64, neg bool) {
+		d := Duration{value: fixed128.FromParts(hi, lo, neg)}
+		s := d.Humanize()
+		if got, err := ParseHumanized(s); err != nil || got.Cmp(d) != 0 {
+			t.Fatalf("ParseHumanized(%q) = %v, %v, want %v", s, got.value, err, d.value)
+		}
+	})
+}
@@ -0,0 +1,98 @@
+package binarytime
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/seannyphoenix/binarytime/pkg/fixed128"
+)
+
+// Zone shifts binary time from UTC to a local reckoning, so that binary
+// midnight falls at local midnight. The offset comes from a time.Location,
+// including its daylight saving rules, from a fixed Duration, or from the
+// longitude for local mean solar time. The zero Zone is UTC.
+type Zone struct {
+	name   string
+	loc    *time.Location
+	offset Duration
+}
+
+// UTC is the zero Zone, which leaves binary time unchanged.
+var UTC = Zone{}
+
+// ZoneFromLocation returns a Zone following the offsets of loc.
+func ZoneFromLocation(loc *time.Location) Zone {
+	if loc == nil || loc == time.UTC {
+		return UTC
+	}
+	return Zone{name: loc.String(), loc: loc}
+}
+
+// LoadZone returns the Zone of the IANA time zone with the given name, as
+// time.LoadLocation does. Programs that may run on systems without a
+// zoneinfo database should import time/tzdata in their main package.
+func LoadZone(name string) (Zone, error) {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return UTC, err
+	}
+	return ZoneFromLocation(loc), nil
+}
+
+// FixedZone returns a Zone that is always offset days ahead of UTC.
+func FixedZone(name string, offset Duration) Zone {
+	return Zone{name: name, offset: offset}
+}
+
+// SolarZone returns the Zone of local mean solar time at the given
+// longitude in degrees, east positive, which is a day ahead of UTC for
+// every 360 degrees. Longitudes outside -180 to 180 are wrapped.
+func SolarZone(longitude float64) Zone {
+	longitude = math.Remainder(longitude, 360)
+	offset, _ := fixed128.FromFloat64(longitude / 360)
+	return Zone{name: fmt.Sprintf("solar%+g", longitude), offset: Duration{value: offset}}
+}
+
+// String returns the name of the Zone.
+func (z Zone) String() string {
+	if z.name == "" && z.loc == nil && z.offset.value.IsZero() {
+		return "UTC"
+	}
+	return z.name
+}
+
+// Location returns the time.Location the Zone follows,
+// or nil for UTC and for fixed and solar zones.
+func (z Zone) Location() *time.Location {
+	return z.loc
+}
+
+// Offset returns how far the Zone is ahead of UTC at the instant d.
+func (z Zone) Offset(d Date) Duration {
+	if z.loc == nil {
+		return z.offset
+	}
+	_, seconds := d.Time().In(z.loc).Zone()
+	return FromDuration(time.Duration(seconds) * time.Second)
+}
+
+// In returns the binary time of the Zone at the instant d: a Date that
+// reads as local binary time, but no longer stands for the same instant.
+// Use Zone.FromLocal to go back.
+func (d Date) In(z Zone) Date {
+	return d.Add(z.Offset(d))
+}
+
+// FromLocal returns the instant at which the Zone reads the local binary time.
+// Where daylight saving repeats or skips a local time, which of the two
+// offsets applies is unspecified, as with time.Date.
+func (z Zone) FromLocal(local Date) Date {
+	guess := local.Add(z.Offset(local).Neg())
+	return local.Add(z.Offset(guess).Neg())
+}
+
+// LocalComponents is like Components, but for the binary time of the Zone.
+func (d Date) LocalComponents(z Zone) []Component {
+	return d.In(z).Components()
+}
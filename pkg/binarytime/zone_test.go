@@ -0,0 +1,85 @@
+package binarytime
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata" // the tests need zones even where the system has none
+
+	"github.com/seannyphoenix/binarytime/pkg/fixed128"
+)
+
+func TestZone(t *testing.T) {
+	newYork, err := LoadZone("America/New_York")
+	if err != nil {
+		t.Fatalf("LoadZone() error = %v", err)
+	}
+
+	tt := []struct {
+		zone   Zone
+		name   string
+		t      time.Time
+		offset fixed128.Fixed128
+	}{
+		{UTC, "UTC", time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC), fixed128.Zero},
+		{ZoneFromLocation(time.UTC), "UTC", time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC), fixed128.Zero},
+		{newYork, "America/New_York", time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC), FromDuration(-5 * time.Hour).value},
+		{newYork, "America/New_York", time.Date(2025, time.July, 1, 0, 0, 0, 0, time.UTC), FromDuration(-4 * time.Hour).value},
+		{FixedZone("HH+1", FromDuration(90*time.Minute)), "HH+1", time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC), fixed128.FromParts(0, 1<<60, false)},
+		{SolarZone(90), "solar+90", time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC), fixed128.FromParts(0, 1<<62, false)},
+		{SolarZone(-450), "solar-90", time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC), fixed128.FromParts(0, 1<<62, true)},
+		{SolarZone(180), "solar+180", time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC), fixed128.FromParts(0, 1<<63, false)},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name+" "+tc.t.Format(time.DateOnly), func(t *testing.T) {
+			if got := tc.zone.String(); got != tc.name {
+				t.Errorf("String() = %q, want %q", got, tc.name)
+			}
+
+			d := DateFromTime(tc.t)
+			if got := tc.zone.Offset(d).value; got.Cmp(tc.offset) != 0 {
+				t.Errorf("Offset() = %v, want %v", got, tc.offset)
+			}
+
+			local := d.In(tc.zone)
+			if got := local.Sub(d).value; got.Cmp(tc.offset) != 0 {
+				t.Errorf("In() is %v ahead, want %v", got, tc.offset)
+			}
+			if got := tc.zone.FromLocal(local); got != d {
+				t.Errorf("FromLocal(In()) = %v, want %v", got.value, d.value)
+			}
+		})
+	}
+}
+
+func TestLocalComponents(t *testing.T) {
+	tokyo, err := LoadZone("Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("LoadZone() error = %v", err)
+	}
+
+	// 20:00 UTC is 05:00 the next day in Tokyo
+	d := DateFromTime(time.Date(2025, time.June, 1, 20, 0, 0, 0, time.UTC))
+	utc, local := d.Components(), d.LocalComponents(tokyo)
+
+	day := func(cs []Component) uint64 {
+		for _, c := range cs {
+			if c.Unit.Granularity == GranularityDay {
+				return c.Count
+			}
+		}
+		return 0
+	}
+	if day(local) != (day(utc)+1)%4 {
+		t.Errorf("LocalComponents() day = %d, want the day after %d", day(local), day(utc))
+	}
+
+	midnight := tokyo.FromLocal(d.In(tokyo).Truncate(GranularityDay))
+	if got := midnight.Time().In(tokyo.Location()); got.Hour() != 0 || got.Minute() != 0 {
+		t.Errorf("local binary midnight is %v, want midnight in Tokyo", got)
+	}
+
+	if _, err := LoadZone("Nowhere/Special"); err == nil {
+		t.Errorf("LoadZone() of an unknown zone succeeded")
+	}
+}
@@ -0,0 +1,72 @@
+// Package btid generates sortable unique identifiers that begin with a
+// binary time. An ID is the big-endian Date, truncated to a Granularity,
+// followed by a counter that keeps IDs from one Generator strictly
+// increasing, and then random bits. IDs are 128 or 192 bits long, and
+// sort the same way as bytes, as hex and as Crockford base32.
+package btid
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/seannyphoenix/binarytime/pkg/binarytime"
+)
+
+// Size is the length of an ID in bytes.
+type Size int
+
+const (
+	Size128 Size = 16
+	Size192 Size = 24
+)
+
+var (
+	ErrInvalidID     = errors.New("invalid btid")
+	ErrInvalidLayout = errors.New("invalid btid layout")
+	ErrExhausted     = errors.New("btid time exhausted")
+)
+
+// ID is a 128- or 192-bit identifier. The zero ID is empty.
+type ID struct {
+	b    [Size192]byte
+	size Size
+}
+
+// FromBytes returns the ID with the given 16 or 24 bytes.
+func FromBytes(b []byte) (ID, error) {
+	if len(b) != int(Size128) && len(b) != int(Size192) {
+		return ID{}, ErrInvalidID
+	}
+	var id ID
+	id.size = Size(copy(id.b[:], b))
+	return id, nil
+}
+
+// Bytes returns the bytes of the ID.
+func (id ID) Bytes() []byte {
+	return bytes.Clone(id.b[:id.size])
+}
+
+// Size returns the length of the ID in bytes, or 0 for the zero ID.
+func (id ID) Size() Size {
+	return id.size
+}
+
+// IsZero reports whether the ID is the empty zero ID.
+func (id ID) IsZero() bool {
+	return id.size == 0
+}
+
+// Compare returns -1, 0 or 1 as id sorts before, with or after other.
+// A 128-bit ID sorts before a 192-bit one with the same first 16 bytes.
+func (id ID) Compare(other ID) int {
+	return bytes.Compare(id.b[:id.size], other.b[:other.size])
+}
+
+// Date returns the binary time at the start of the ID, given the
+// Granularity it was generated with. Only the bits down to that
+// Granularity are time; the rest are dropped.
+func (id ID) Date(g binarytime.Granularity) binarytime.Date {
+	d, _ := binarytime.DateFromBytes(id.b[:16])
+	return d.Truncate(g)
+}
@@ -0,0 +1,272 @@
+package btid
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/seannyphoenix/binarytime/pkg/binarytime"
+	"github.com/seannyphoenix/binarytime/pkg/fixed128"
+)
+
+func date(hi, lo uint64) binarytime.Date {
+	d, _ := binarytime.DateFromFixed128(fixed128.FromParts(hi, lo, false))
+	return d
+}
+
+func TestNewGenerator(t *testing.T) {
+	tt := []struct {
+		size        Size
+		g           binarytime.Granularity
+		counterBits int
+		ok          bool
+	}{
+		{Size128, binarytime.GranularityFemtobit, 16, true},
+		{Size128, binarytime.GranularityTick, 0, true},
+		{Size128, binarytime.GranularityTick, 1, false},
+		{Size192, binarytime.GranularityTick, 64, true},
+		{Size192, binarytime.GranularityEon, 64, true},
+		{Size192, binarytime.GranularityDay, 65, false},
+		{Size128, binarytime.GranularityDay, -1, false},
+		{Size128, binarytime.Granularity(65), 0, false},
+		{Size(20), binarytime.GranularityDay, 0, false},
+	}
+
+	for _, tc := range tt {
+		_, err := NewGenerator(tc.size, tc.g, tc.counterBits)
+		if tc.ok && err != nil {
+			t.Errorf("NewGenerator(%d, %v, %d) error = %v", tc.size, tc.g, tc.counterBits, err)
+		}
+		if !tc.ok && !errors.Is(err, ErrInvalidLayout) {
+			t.Errorf("NewGenerator(%d, %v, %d) error = %v, want ErrInvalidLayout", tc.size, tc.g, tc.counterBits, err)
+		}
+	}
+}
+
+func TestGeneratorLayout(t *testing.T) {
+	clock := binarytime.NewManualClock(date(0x40000004e2c, 0x9f3b7a5c1d2e4f68))
+	gen, _ := NewGenerator(Size128, binarytime.GranularityFemtobit, 12)
+	gen.Clock = clock
+	gen.Rand = bytes.NewReader(bytes.Repeat([]byte{0xff}, 32))
+
+	first, err := gen.New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	// 96 bits of time, a zero 12-bit counter and 20 random bits
+	if got, want := first.Hex(), "0000040000004e2c9f3b7a5c000fffff"; got != want {
+		t.Errorf("New() = %s, want %s", got, want)
+	}
+	if got := first.Date(gen.Granularity()); got != date(0x40000004e2c, 0x9f3b7a5c00000000) {
+		t.Errorf("Date() = %v", got.Fixed128())
+	}
+
+	second, _ := gen.New()
+	if got, want := second.Hex(), "0000040000004e2c9f3b7a5c001fffff"; got != want {
+		t.Errorf("New() with the clock stopped = %s, want %s", got, want)
+	}
+
+	if _, err := gen.New(); err == nil {
+		t.Errorf("New() with the random bits used up succeeded")
+	}
+}
+
+func TestZeroGenerator(t *testing.T) {
+	var gen Generator
+	gen.Clock = binarytime.NewManualClock(date(0x40000004e2c, 0x9f3b7a5c1d2e4f68))
+	gen.Rand = bytes.NewReader(make([]byte, 32))
+
+	if got := gen.Granularity(); got != binarytime.GranularityFemtobit {
+		t.Errorf("Granularity() = %v, want %v", got, binarytime.GranularityFemtobit)
+	}
+	first, err := gen.New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	second, _ := gen.New()
+	// 96 bits of time, a 16-bit counter and 16 random bits, as New makes
+	if got, want := first.Hex(), "0000040000004e2c9f3b7a5c00000000"; got != want || first.Size() != Size128 {
+		t.Errorf("New() = %s, want %s", got, want)
+	}
+	if got, want := second.Hex(), "0000040000004e2c9f3b7a5c00010000"; got != want {
+		t.Errorf("New() with the clock stopped = %s, want %s", got, want)
+	}
+}
+
+func TestGeneratorCounterOverflow(t *testing.T) {
+	start := date(0x1000, 0)
+	clock := binarytime.NewManualClock(start)
+	gen, _ := NewGenerator(Size128, binarytime.GranularityHexHour, 2)
+	gen.Clock = clock
+
+	// Four IDs fit in a hexhour, then the time runs ahead
+	var ids []ID
+	for range 9 {
+		id, err := gen.New()
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		ids = append(ids, id)
+	}
+	want := []binarytime.Date{start, start, start, start, date(0x1000, 1<<60), date(0x1000, 1<<60), date(0x1000, 1<<60), date(0x1000, 1<<60), date(0x1000, 2<<60)}
+	for i, id := range ids {
+		if got := id.Date(binarytime.GranularityHexHour); got != want[i] {
+			t.Errorf("ID %d Date() = %v, want %v", i, got.Fixed128(), want[i].Fixed128())
+		}
+	}
+	if !slices.IsSortedFunc(ids, ID.Compare) {
+		t.Errorf("IDs are not increasing: %v", ids)
+	}
+
+	// Stepping the clock back doesn't break the order
+	clock.SetTo(date(0xfff, 0))
+	if id, _ := gen.New(); id.Compare(ids[len(ids)-1]) <= 0 {
+		t.Errorf("New() after the clock stepped back = %v, want after %v", id, ids[len(ids)-1])
+	}
+
+	last, _ := NewGenerator(Size128, binarytime.GranularityTick, 0)
+	last.Clock = binarytime.NewManualClock(date(^uint64(0), ^uint64(0)))
+	if _, err := last.New(); err != nil {
+		t.Fatalf("New() at the last Date error = %v", err)
+	}
+	if _, err := last.New(); !errors.Is(err, ErrExhausted) {
+		t.Errorf("New() past the last Date error = %v, want ErrExhausted", err)
+	}
+}
+
+func TestGeneratorConcurrent(t *testing.T) {
+	gen, _ := NewGenerator(Size192, binarytime.GranularityNanobit, 8)
+
+	const goroutines, each = 8, 500
+	results := make([][]ID, goroutines)
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range each {
+				id, err := gen.New()
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				results[i] = append(results[i], id)
+			}
+		}()
+	}
+	wg.Wait()
+
+	seen := make(map[ID]bool)
+	for _, ids := range results {
+		if !slices.IsSortedFunc(ids, ID.Compare) {
+			t.Errorf("IDs from one goroutine are not increasing")
+		}
+		for _, id := range ids {
+			if seen[id] {
+				t.Fatalf("duplicate ID %v", id)
+			}
+			seen[id] = true
+		}
+	}
+}
+
+func TestEncoding(t *testing.T) {
+	zero, _ := FromBytes(make([]byte, 16))
+	ones, _ := FromBytes(bytes.Repeat([]byte{0xff}, 24))
+	id, _ := FromBytes([]byte{0x00, 0x00, 0x04, 0x00, 0x00, 0x00, 0x4e, 0x2c, 0x9f, 0x3b, 0x7a, 0x5c, 0x1d, 0x2e, 0x4f, 0x68})
+
+	tt := []struct {
+		id     ID
+		hex    string
+		base32 string
+	}{
+		{zero, strings.Repeat("0", 32), strings.Repeat("0", 26)},
+		{ones, strings.Repeat("f", 48), "3" + strings.Repeat("Z", 38)},
+		{id, "0000040000004e2c9f3b7a5c1d2e4f68", "00002000009RP9YEVTBGEJWKV8"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.hex, func(t *testing.T) {
+			if got := tc.id.Hex(); got != tc.hex {
+				t.Errorf("Hex() = %s, want %s", got, tc.hex)
+			}
+			if got := tc.id.Base32(); got != tc.base32 {
+				t.Errorf("Base32() = %s, want %s", got, tc.base32)
+			}
+			for _, s := range []string{tc.hex, tc.base32, strings.ToLower(tc.base32)} {
+				if got, err := Parse(s); err != nil || got != tc.id {
+					t.Errorf("Parse(%s) = %v, %v, want %v", s, got, err, tc.id)
+				}
+			}
+		})
+	}
+
+	want, _ := Parse("010100" + strings.Repeat("0", 20))
+	if got, err := Parse("0i0L0o" + strings.Repeat("0", 20)); err != nil || got != want {
+		t.Errorf("Parse() with I, L and O = %v, %v, want %v", got, err, want)
+	}
+	for _, s := range []string{"8" + strings.Repeat("0", 25), "U" + strings.Repeat("0", 25), strings.Repeat("g", 32), "4" + strings.Repeat("0", 38)} {
+		if _, err := Parse(s); !errors.Is(err, ErrInvalidID) {
+			t.Errorf("Parse(%q) error = %v, want ErrInvalidID", s, err)
+		}
+	}
+}
+
+func TestZeroIDRoundTrip(t *testing.T) {
+	var zero ID
+	text, err := zero.MarshalText()
+	if err != nil || len(text) != 0 {
+		t.Fatalf("MarshalText() = %q, %v, want nothing", text, err)
+	}
+	back := New()
+	if err := back.UnmarshalText(text); err != nil || !back.IsZero() {
+		t.Errorf("UnmarshalText(%q) = %v, %v, want the zero ID", text, back, err)
+	}
+	if got, err := Parse(zero.String()); err != nil || !got.IsZero() {
+		t.Errorf("Parse(%q) = %v, %v, want the zero ID", zero.String(), got, err)
+	}
+
+	type row struct {
+		ID ID `json:"id"`
+	}
+	data, err := json.Marshal(row{})
+	if err != nil || string(data) != `{"id":""}` {
+		t.Fatalf("Marshal() = %s, %v", data, err)
+	}
+	decoded := row{ID: New()}
+	if err := json.Unmarshal(data, &decoded); err != nil || !decoded.ID.IsZero() {
+		t.Errorf("Unmarshal(%s) = %v, %v, want the zero ID", data, decoded.ID, err)
+	}
+}
+
+func FuzzEncodingRoundTrip(f *testing.F) {
+	f.Add(make([]byte, 16), make([]byte, 16))
+	f.Add(bytes.Repeat([]byte{0xff}, 24), make([]byte, 24))
+	f.Add([]byte("0123456789abcdef"), []byte("0123456789abcdeg"))
+
+	f.Fuzz(func(t *testing.T, a, b []byte) {
+		x, errX := FromBytes(a)
+		y, errY := FromBytes(b)
+		if errX != nil || errY != nil || x.Size() != y.Size() {
+			return
+		}
+
+		for _, s := range []string{x.Hex(), x.Base32()} {
+			if got, err := Parse(s); err != nil || got != x {
+				t.Fatalf("Parse(%s) = %v, %v, want %v", s, got, err, x)
+			}
+		}
+
+		want := x.Compare(y)
+		if got := strings.Compare(x.Base32(), y.Base32()); got != want {
+			t.Errorf("Base32 order = %d, want %d", got, want)
+		}
+		if got := strings.Compare(x.Hex(), y.Hex()); got != want {
+			t.Errorf("Hex order = %d, want %d", got, want)
+		}
+	})
+}
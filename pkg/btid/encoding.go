@@ -0,0 +1,115 @@
+package btid
+
+import (
+	"encoding"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// crockford is Douglas Crockford's base32 alphabet, which leaves out
+// I, L, O and U and sorts in the same order as the values it encodes.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+var (
+	_ encoding.TextMarshaler   = ID{}
+	_ encoding.TextUnmarshaler = (*ID)(nil)
+)
+
+// Hex returns the ID as lowercase hex, 32 or 48 digits long.
+func (id ID) Hex() string {
+	return hex.EncodeToString(id.b[:id.size])
+}
+
+// Base32 returns the ID in Crockford base32, 26 or 39 digits long. The
+// ID is padded with leading zero bits to a whole number of digits, so the
+// first digit is at most 7 for 128-bit IDs and at most 3 for 192-bit ones.
+func (id ID) Base32() string {
+	n := base32Len(id.size)
+	out := make([]byte, n)
+	pad := 5*n - 8*int(id.size)
+	for i := range out {
+		var v byte
+		for j := range 5 {
+			pos := 5*i + j - pad
+			v <<= 1
+			if pos >= 0 && id.b[pos/8]&(0x80>>(pos%8)) != 0 {
+				v |= 1
+			}
+		}
+		out[i] = crockford[v]
+	}
+	return string(out)
+}
+
+// String returns the Base32 form, or the empty string for the zero ID.
+func (id ID) String() string {
+	return id.Base32()
+}
+
+// MarshalText returns the Base32 form, or nothing for the zero ID,
+// which UnmarshalText reads back as the zero ID.
+func (id ID) MarshalText() ([]byte, error) {
+	return []byte(id.Base32()), nil
+}
+
+func (id *ID) UnmarshalText(text []byte) error {
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
+}
+
+// Parse reads an ID in either the Hex or the Base32 form, telling them
+// apart by length. Base32 is read case-insensitively, with I and L read
+// as 1 and O as 0. The empty string, which String returns for the zero
+// ID, parses as the zero ID.
+func Parse(s string) (ID, error) {
+	switch len(s) {
+	case 0:
+		return ID{}, nil
+	case 2 * int(Size128), 2 * int(Size192):
+		b, err := hex.DecodeString(s)
+		if err != nil {
+			return ID{}, fmt.Errorf("%w: %q", ErrInvalidID, s)
+		}
+		return FromBytes(b)
+	case base32Len(Size128):
+		return parseBase32(s, Size128)
+	case base32Len(Size192):
+		return parseBase32(s, Size192)
+	default:
+		return ID{}, fmt.Errorf("%w: %q has length %d", ErrInvalidID, s, len(s))
+	}
+}
+
+func parseBase32(s string, size Size) (ID, error) {
+	id := ID{size: size}
+	pad := 5*len(s) - 8*int(size)
+	for i := range len(s) {
+		c := strings.ToUpper(s[i : i+1])
+		switch c {
+		case "I", "L":
+			c = "1"
+		case "O":
+			c = "0"
+		}
+		v := strings.Index(crockford, c)
+		if v < 0 || i == 0 && v >= 1<<(5-pad) {
+			return ID{}, fmt.Errorf("%w: %q at offset %d", ErrInvalidID, s, i)
+		}
+		for j := range 5 {
+			pos := 5*i + j - pad
+			if pos >= 0 && v&(0x10>>j) != 0 {
+				id.b[pos/8] |= 0x80 >> (pos % 8)
+			}
+		}
+	}
+	return id, nil
+}
+
+func base32Len(size Size) int {
+	return (8*int(size) + 4) / 5
+}
@@ -0,0 +1,165 @@
+package btid
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/bits"
+	"sync"
+
+	"github.com/seannyphoenix/binarytime/pkg/binarytime"
+)
+
+// Generator makes IDs that strictly increase, even when called from
+// several goroutines at once or when the clock stands still or steps
+// back. When the counter runs out within one step of the Granularity,
+// the time in the IDs runs ahead of the clock by a step until the clock
+// catches up.
+//
+// The zero Generator is ready to use, making the same 128-bit IDs as New.
+type Generator struct {
+	// Clock is the source of time, SystemClock if nil.
+	Clock binarytime.Clock
+	// Rand is the source of the random bits, crypto/rand if nil.
+	Rand io.Reader
+
+	layout layout
+
+	mu        sync.Mutex
+	lastHi    uint64
+	lastLo    uint64
+	counter   uint64
+	generated bool
+}
+
+// NewGenerator returns a Generator of IDs of the given size, keeping the
+// time down to the given Granularity, from GranularityEon to
+// GranularityTick, followed by counterBits bits of counter, up to 64.
+// Any bits left over are random.
+func NewGenerator(size Size, g binarytime.Granularity, counterBits int) (*Generator, error) {
+	timeBits := 64 + int(g)
+	if size != Size128 && size != Size192 {
+		return nil, fmt.Errorf("%w: size %d", ErrInvalidLayout, size)
+	}
+	if g < binarytime.GranularityEon || g > binarytime.GranularityTick {
+		return nil, fmt.Errorf("%w: granularity %v", ErrInvalidLayout, g)
+	}
+	if counterBits < 0 || counterBits > 64 || timeBits+counterBits > 8*int(size) {
+		return nil, fmt.Errorf("%w: %d counter bits after %d time bits", ErrInvalidLayout, counterBits, timeBits)
+	}
+	return &Generator{layout: layout{size, g, counterBits}}, nil
+}
+
+// layout is how a Generator divides up its IDs.
+type layout struct {
+	size        Size
+	granularity binarytime.Granularity
+	counterBits int
+}
+
+// defaultLayout is the layout of the zero Generator.
+var defaultLayout = layout{Size128, binarytime.GranularityFemtobit, 16}
+
+// getLayout returns the layout of g, or defaultLayout for the zero Generator.
+func (g *Generator) getLayout() layout {
+	if g.layout.size == 0 {
+		return defaultLayout
+	}
+	return g.layout
+}
+
+var defaultGenerator Generator
+
+// New returns a 128-bit ID from the default Generator, with time to the
+// femtobit, about 20 microseconds, a 16-bit counter and 16 random bits.
+func New() ID {
+	id, err := defaultGenerator.New()
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// Granularity returns the precision of the time in the Generator's IDs.
+func (g *Generator) Granularity() binarytime.Granularity {
+	return g.getLayout().granularity
+}
+
+// New returns the next ID. It fails if reading the random bits fails, or
+// if the time would have to run past the last Date.
+func (g *Generator) New() (ID, error) {
+	l := g.getLayout()
+	id := ID{size: l.size}
+	if g.Rand == nil {
+		rand.Read(id.b[:l.size])
+	} else if _, err := io.ReadFull(g.Rand, id.b[:l.size]); err != nil {
+		return ID{}, fmt.Errorf("btid: reading random bits: %w", err)
+	}
+
+	clock := g.Clock
+	if clock == nil {
+		clock = binarytime.SystemClock
+	}
+	now := clock.Now().Truncate(l.granularity)
+
+	g.mu.Lock()
+	hi, lo, counter, err := g.next(now, l)
+	g.mu.Unlock()
+	if err != nil {
+		return ID{}, err
+	}
+
+	// Lay the time and counter over the random bits
+	var prefix [Size192]byte
+	binary.BigEndian.PutUint64(prefix[0:], hi)
+	binary.BigEndian.PutUint64(prefix[8:], lo)
+	timeBits := 64 + int(l.granularity)
+	for i := range l.counterBits {
+		if counter>>(l.counterBits-1-i)&1 == 1 {
+			pos := timeBits + i
+			prefix[pos/8] |= 0x80 >> (pos % 8)
+		}
+	}
+
+	n := timeBits + l.counterBits
+	copy(id.b[:n/8], prefix[:n/8])
+	if n%8 != 0 {
+		mask := byte(0xff) >> (n % 8)
+		id.b[n/8] = prefix[n/8] | id.b[n/8]&mask
+	}
+	return id, nil
+}
+
+// next returns the time and counter of the next ID, given the truncated
+// time now and the layout of g. It must be called with g.mu held.
+func (g *Generator) next(now binarytime.Date, l layout) (hi, lo, counter uint64, err error) {
+	hi, lo, _ = now.Fixed128().Parts()
+
+	ahead := g.generated && (hi < g.lastHi || hi == g.lastHi && lo <= g.lastLo)
+	if !ahead {
+		g.lastHi, g.lastLo, g.counter, g.generated = hi, lo, 0, true
+		return hi, lo, 0, nil
+	}
+
+	if g.counter < 1<<l.counterBits-1 {
+		g.counter++
+		return g.lastHi, g.lastLo, g.counter, nil
+	}
+
+	// The counter is spent, so take the next step of time
+	step := 128 - (64 + int(l.granularity))
+	var stepHi, stepLo uint64
+	if step >= 64 {
+		stepHi = 1 << (step - 64)
+	} else {
+		stepLo = 1 << step
+	}
+	lo, carry := bits.Add64(g.lastLo, stepLo, 0)
+	hi, carry = bits.Add64(g.lastHi, stepHi, carry)
+	if carry != 0 {
+		return 0, 0, 0, ErrExhausted
+	}
+	g.lastHi, g.lastLo, g.counter = hi, lo, 0
+	return hi, lo, 0, nil
+}
@@ -12,3 +12,9 @@ func Glyphs(vs []byte, dot int) string {
 
 	return assembleHorizontalGlyphs(hgs, dot)
 }
+
+// Parse reverses Glyphs, returning the bytes drawn in s and the position
+// of the dot, or -1 if there is none.
+func Parse(s string) ([]byte, int, error) {
+	return parseHorizontalGlyphs(s)
+}
@@ -0,0 +1,49 @@
+package byteglyph
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tt := []struct {
+		name string
+		s    string
+		err  error
+	}{
+		{"bad bar", "|\\/|\n-- -\n|/\\|\n\n", ErrInvalidGlyph},
+		{"bad stroke", "|\\/|\n----\n|/\\/\n\n", ErrInvalidGlyph},
+		{"truncated", "|\\/|\n----\n", ErrInvalidGlyph},
+		{"two dots", "*   \n\n*   \n\n", ErrInvalidGlyph},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, _, err := Parse(tc.s); !errors.Is(err, tc.err) {
+				t.Errorf("Parse(%q) error = %v, want %v", tc.s, err, tc.err)
+			}
+		})
+	}
+}
+
+func FuzzGlyphsRoundTrip(f *testing.F) {
+	f.Add([]byte{0x00, 0xff, 0xa5, 0x5a}, 2)
+	f.Add([]byte{0x81}, 0)
+	f.Add([]byte{}, 0)
+
+	f.Fuzz(func(t *testing.T, vs []byte, dot int) {
+		if dot < 0 || dot > len(vs) {
+			dot = -1
+		}
+
+		s := Glyphs(vs, dot)
+		got, gotDot, err := Parse(s)
+		if err != nil {
+			t.Fatalf("Parse(%q) error = %v", s, err)
+		}
+		if !bytes.Equal(got, vs) || gotDot != dot {
+			t.Fatalf("Parse(Glyphs(%x, %d)) = %x, %d", vs, dot, got, gotDot)
+		}
+	})
+}
@@ -1,10 +1,13 @@
 package byteglyph
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 )
 
+var ErrInvalidGlyph = errors.New("invalid glyph")
+
 var (
 	emptyH = []byte(`    `)
 	highH  = []byte(`|\/|`)
@@ -67,3 +70,61 @@ func assembleHorizontalGlyphs(hgs []horizontalGlyph, dot int) string {
 	}
 	return sb.String()
 }
+
+// parseHorizontalGlyph reads back the byte drawn by newHorizontalGlyph.
+func parseHorizontalGlyph(high, bar, low string) (byte, bool) {
+	if len(high) != 4 || len(low) != 4 || bar != string(barH) {
+		return 0, false
+	}
+
+	var b byte
+	for i, half := range [][]byte{[]byte(high), []byte(low)} {
+		lit := highH
+		if i == 1 {
+			lit = lowH
+		}
+		for j := range 4 {
+			b <<= 1
+			switch half[j] {
+			case lit[j]:
+				b |= 1
+			case emptyH[j]:
+			default:
+				return 0, false
+			}
+		}
+	}
+	return b, true
+}
+
+func parseHorizontalGlyphs(s string) ([]byte, int, error) {
+	lines := strings.Split(s, "\n")
+	if len(lines) == 0 || lines[len(lines)-1] != "" {
+		return nil, -1, fmt.Errorf("%w: missing final newline", ErrInvalidGlyph)
+	}
+	lines = lines[:len(lines)-1]
+
+	var vs []byte
+	dot := -1
+	for i := 0; i < len(lines); {
+		if lines[i] == string(dotH) {
+			if dot >= 0 || i+1 >= len(lines) || lines[i+1] != "" {
+				return nil, -1, fmt.Errorf("%w: bad dot at line %d", ErrInvalidGlyph, i+1)
+			}
+			dot = len(vs)
+			i += 2
+			continue
+		}
+
+		if i+3 >= len(lines) || lines[i+3] != "" {
+			return nil, -1, fmt.Errorf("%w: incomplete glyph at line %d", ErrInvalidGlyph, i+1)
+		}
+		b, ok := parseHorizontalGlyph(lines[i], lines[i+1], lines[i+2])
+		if !ok {
+			return nil, -1, fmt.Errorf("%w: unreadable glyph at line %d", ErrInvalidGlyph, i+1)
+		}
+		vs = append(vs, b)
+		i += 4
+	}
+	return vs, dot, nil
+}
@@ -0,0 +1,163 @@
+package fixed128
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/big"
+)
+
+var (
+	ErrorNaN      = errors.New("not a number")
+	ErrorInfinity = errors.New("infinite value")
+)
+
+// FromFloat64 converts a float64 to a Fixed128, rounding to nearest with
+// ties to even. It returns an error for NaN, infinities and values whose
+// magnitude is 2^64 or more.
+func FromFloat64(x float64) (Fixed128, error) {
+	switch {
+	case math.IsNaN(x):
+		return Zero, ErrorNaN
+	case math.IsInf(x, 0):
+		return Zero, ErrorInfinity
+	case x == 0:
+		return Zero, nil
+	}
+
+	// x = mant * 2^(exp-53), so the 64.64 value is mant * 2^(exp+11)
+	neg := x < 0
+	frac, exp := math.Frexp(math.Abs(x))
+	mant := uint64(frac * (1 << 53))
+	shift := exp + 11
+
+	if shift >= 0 {
+		f128, err := lsh(Fixed128{lo: mant}, uint(shift))
+		if err != nil {
+			return Zero, ErrorOutOfRange
+		}
+		return signed(f128.hi, f128.lo, neg), nil
+	}
+
+	// The mantissa has at most 53 bits, so shifting it
+	// 64 or more places always rounds to zero
+	if shift <= -64 {
+		return Zero, nil
+	}
+	unit := uint64(1) << -shift
+	lo := mant >> -shift
+	discarded := mant & (unit - 1)
+	if RoundHalfEven.roundUp(neg, lo&1 == 1, discarded != 0, halfCmp(discarded, unit)) {
+		lo++
+	}
+	return signed(0, lo, neg), nil
+}
+
+// Float64 returns the nearest float64 to the Fixed128, with ties to even.
+func (f128 Fixed128) Float64() float64 {
+	abs := f128.Abs()
+	length := bitLenWords([]uint64{abs.lo, abs.hi})
+
+	var x float64
+	if length <= 53 {
+		x = math.Ldexp(float64(abs.lo), -64)
+	} else {
+		// Keep the top 53 bits, rounding on the first dropped bit
+		// and whether any bits below it are set
+		shift := uint(length - 53)
+		mant := rsh(abs, shift).lo
+		half := rsh(abs, shift-1)
+		back, _ := lsh(half, shift-1)
+		if half.lo&1 == 1 && (back != abs || mant&1 == 1) {
+			mant++
+		}
+		x = math.Ldexp(float64(mant), int(shift)-64)
+	}
+
+	if f128.neg {
+		return -x
+	}
+	return x
+}
+
+// FromBigInt converts a whole number to a Fixed128.
+// It returns an error if its magnitude is 2^64 or more.
+func FromBigInt(x *big.Int) (Fixed128, error) {
+	return FromScaledBigInt(new(big.Int).Lsh(x, 64))
+}
+
+// FromScaledBigInt converts x / 2^64 to a Fixed128, so that x is the
+// 128-bit integer underlying the fixed-point layout.
+// It returns an error if the magnitude of x is 2^128 or more.
+func FromScaledBigInt(x *big.Int) (Fixed128, error) {
+	if x.BitLen() > 128 {
+		return Zero, ErrorOutOfRange
+	}
+
+	var b [16]byte
+	new(big.Int).Abs(x).FillBytes(b[:])
+	return signed(binary.BigEndian.Uint64(b[:8]), binary.BigEndian.Uint64(b[8:]), x.Sign() < 0), nil
+}
+
+// ScaledBigInt returns the Fixed128 multiplied by 2^64,
+// the integer underlying the fixed-point layout.
+func (f128 Fixed128) ScaledBigInt() *big.Int {
+	x := new(big.Int).SetUint64(f128.hi)
+	x.Lsh(x, 64)
+	x.Or(x, new(big.Int).SetUint64(f128.lo))
+	if f128.neg {
+		x.Neg(x)
+	}
+	return x
+}
+
+// FromBigRat converts a big.Rat to a Fixed128, rounding to nearest with
+// ties to even. It returns an error if the magnitude is 2^64 or more.
+func FromBigRat(r *big.Rat) (Fixed128, error) {
+	num := new(big.Int).Abs(r.Num())
+	num.Lsh(num, 64)
+
+	q, rem := num.QuoRem(num, r.Denom(), new(big.Int))
+	half := rem.Lsh(rem, 1).Cmp(r.Denom())
+	if half > 0 || (half == 0 && q.Bit(0) == 1) {
+		q.Add(q, big.NewInt(1))
+	}
+
+	if r.Sign() < 0 {
+		q.Neg(q)
+	}
+	return FromScaledBigInt(q)
+}
+
+// BigRat returns the exact value of the Fixed128 as a big.Rat.
+func (f128 Fixed128) BigRat() *big.Rat {
+	scale := new(big.Int).Lsh(big.NewInt(1), 64)
+	return new(big.Rat).SetFrac(f128.ScaledBigInt(), scale)
+}
+
+// FromBigFloat converts a big.Float to a Fixed128, rounding to nearest
+// with ties to even. It returns an error for infinities and values whose
+// magnitude is 2^64 or more.
+func FromBigFloat(x *big.Float) (Fixed128, error) {
+	if x.IsInf() {
+		return Zero, ErrorInfinity
+	}
+
+	// Check the range before x.Rat, which would build an integer as large
+	// as the exponent. |x| < 2^exp, and below 2^-65 it rounds to zero.
+	switch exp := x.MantExp(nil); {
+	case exp > 64:
+		return Zero, ErrorOutOfRange
+	case exp < -64:
+		return Zero, nil
+	}
+	r, _ := x.Rat(nil)
+	return FromBigRat(r)
+}
+
+// BigFloat returns the exact value of the Fixed128 as a big.Float
+// with 128 bits of precision.
+func (f128 Fixed128) BigFloat() *big.Float {
+	x := new(big.Float).SetPrec(128).SetInt(f128.ScaledBigInt())
+	return x.SetMantExp(x, -64)
+}
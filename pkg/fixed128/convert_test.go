@@ -0,0 +1,169 @@
+package fixed128
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestFromFloat64(t *testing.T) {
+	tt := []struct {
+		x    float64
+		want Fixed128
+		err  error
+	}{
+		{0.5, Fixed128{lo: 1 << 63}, nil},
+		{-1.25, Fixed128{hi: 1, lo: 1 << 62, neg: true}, nil},
+		{math.Copysign(0, -1), Fixed128{}, nil},
+		{0x1p-64, Fixed128{lo: 1}, nil},
+		{0x1p-65, Fixed128{}, nil},
+		{0x3p-65, Fixed128{lo: 2}, nil},
+		{0x5p-66, Fixed128{lo: 1}, nil},
+		{-0x1.8p-64, Fixed128{lo: 2, neg: true}, nil},
+		{0x1p-1000, Fixed128{}, nil},
+		{0x1.fffffffffffffp63, Fixed128{hi: 0xfffffffffffff800}, nil},
+		{0x1p64, Fixed128{}, ErrorOutOfRange},
+		{math.NaN(), Fixed128{}, ErrorNaN},
+		{math.Inf(-1), Fixed128{}, ErrorInfinity},
+	}
+
+	for _, tc := range tt {
+		t.Run("", func(t *testing.T) {
+			got, err := FromFloat64(tc.x)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("FromFloat64(%v) error = %v, want %v", tc.x, err, tc.err)
+			}
+			if got != tc.want {
+				t.Errorf("FromFloat64(%v) = %v, want %v", tc.x, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFloat64(t *testing.T) {
+	tt := []struct {
+		f128 Fixed128
+		want float64
+	}{
+		{Fixed128{}, 0},
+		{Fixed128{hi: 1, lo: 1 << 62, neg: true}, -1.25},
+		{Fixed128{lo: 0x5555555555555555}, 1.0 / 3},
+		{Fixed128{lo: 1}, 0x1p-64},
+		{Fixed128{hi: ^uint64(0), lo: ^uint64(0)}, 0x1p64},
+		{Fixed128{hi: 1, lo: 1 << 12}, 1 + 0x1p-52},
+		{Fixed128{hi: 1, lo: 1 << 11}, 1},
+		{Fixed128{hi: 1, lo: 3 << 11}, 1 + 0x2p-52},
+		{Fixed128{hi: 1, lo: 1<<11 + 1}, 1 + 0x1p-52},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.f128.String(), func(t *testing.T) {
+			if got := tc.f128.Float64(); got != tc.want {
+				t.Errorf("Float64(%v) = %v, want %v", tc.f128, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBigConversions(t *testing.T) {
+	f128 := Fixed128{hi: 3, lo: 1 << 62, neg: true}
+
+	if got := f128.BigRat(); got.Cmp(big.NewRat(-13, 4)) != 0 {
+		t.Errorf("BigRat(%v) = %v, want -13/4", f128, got)
+	}
+	if got, _ := f128.BigFloat().Float64(); got != -3.25 {
+		t.Errorf("BigFloat(%v) = %v, want -3.25", f128, got)
+	}
+	if got := f128.ScaledBigInt(); got.Cmp(new(big.Int).Lsh(big.NewInt(-13), 62)) != 0 {
+		t.Errorf("ScaledBigInt(%v) = %v", f128, got)
+	}
+
+	third, err := FromBigRat(big.NewRat(1, 3))
+	if err != nil || third != (Fixed128{lo: 0x5555555555555555}) {
+		t.Errorf("FromBigRat(1/3) = %v, %v", third, err)
+	}
+	twoThirds, err := FromBigRat(big.NewRat(-2, 3))
+	if err != nil || twoThirds != (Fixed128{lo: 0xaaaaaaaaaaaaaaab, neg: true}) {
+		t.Errorf("FromBigRat(-2/3) = %v, %v", twoThirds, err)
+	}
+	whole, err := FromBigInt(big.NewInt(-42))
+	if err != nil || whole != (Fixed128{hi: 42, neg: true}) {
+		t.Errorf("FromBigInt(-42) = %v, %v", whole, err)
+	}
+
+	huge := new(big.Int).Lsh(big.NewInt(1), 64)
+	if _, err := FromBigInt(huge); !errors.Is(err, ErrorOutOfRange) {
+		t.Errorf("FromBigInt(2^64) error = %v, want %v", err, ErrorOutOfRange)
+	}
+	if _, err := FromBigFloat(new(big.Float).SetInf(false)); !errors.Is(err, ErrorInfinity) {
+		t.Errorf("FromBigFloat(+Inf) error = %v, want %v", err, ErrorInfinity)
+	}
+
+	// Extreme exponents are settled without building the exact value
+	vast := new(big.Float).SetMantExp(big.NewFloat(-1), big.MaxExp-1)
+	if _, err := FromBigFloat(vast); !errors.Is(err, ErrorOutOfRange) {
+		t.Errorf("FromBigFloat(-2^MaxExp) error = %v, want %v", err, ErrorOutOfRange)
+	}
+	tiny := new(big.Float).SetMantExp(big.NewFloat(1), big.MinExp)
+	if got, err := FromBigFloat(tiny); err != nil || got != Zero {
+		t.Errorf("FromBigFloat(2^MinExp) = %v, %v, want 0", got, err)
+	}
+	for _, x := range []float64{0x1p-65, 0x1.0000000000001p-65, 0x1p64, -0x1.fffffffffffffp63} {
+		want, werr := FromBigRat(new(big.Rat).SetFloat64(x))
+		if got, err := FromBigFloat(big.NewFloat(x)); got != want || !errors.Is(err, werr) {
+			t.Errorf("FromBigFloat(%v) = %v, %v, want %v, %v", x, got, err, want, werr)
+		}
+	}
+}
+
+func FuzzFloat64(f *testing.F) {
+	f.Add(0.5)
+	f.Add(-1.0 / 3)
+	f.Add(0x1.8p-64)
+	f.Add(0x1.fffffffffffffp63)
+	f.Add(math.Inf(1))
+
+	f.Fuzz(func(t *testing.T, x float64) {
+		got, err := FromFloat64(x)
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			if err == nil {
+				t.Fatalf("FromFloat64(%v) = %v, want error", x, got)
+			}
+			return
+		}
+
+		want, werr := FromBigFloat(big.NewFloat(x))
+		if !errors.Is(err, werr) || got != want {
+			t.Fatalf("FromFloat64(%v) = %v, %v, want %v, %v", x, got, err, want, werr)
+		}
+		if err != nil {
+			return
+		}
+
+		// Converting back rounds again, so compare against big.Float
+		back, _ := got.BigFloat().Float64()
+		if got.Float64() != back {
+			t.Fatalf("Float64(%v) = %v, want %v", got, got.Float64(), back)
+		}
+	})
+}
+
+func FuzzBigRat(f *testing.F) {
+	f.Add(uint64(0), uint64(1), false)
+	f.Add(uint64(1<<42), uint64(1<<63), true)
+	f.Add(^uint64(0), ^uint64(0), false)
+
+	f.Fuzz(func(t *testing.T, hi, lo uint64, neg bool) {
+		f128 := signed(hi, lo, neg)
+		if got, err := FromBigRat(f128.BigRat()); err != nil || got != f128 {
+			t.Fatalf("FromBigRat(BigRat(%v)) = %v, %v", f128, got, err)
+		}
+		if got, err := FromBigFloat(f128.BigFloat()); err != nil || got != f128 {
+			t.Fatalf("FromBigFloat(BigFloat(%v)) = %v, %v", f128, got, err)
+		}
+		if got, err := FromScaledBigInt(f128.ScaledBigInt()); err != nil || got != f128 {
+			t.Fatalf("FromScaledBigInt(ScaledBigInt(%v)) = %v, %v", f128, got, err)
+		}
+	})
+}
@@ -0,0 +1,292 @@
+package fixed128
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/seannyphoenix/binarytime/pkg/fixed128legacy"
+)
+
+// The fuzz tests in this file feed identical inputs to Fixed128 and to the
+// big.Int backed reference in fixed128legacy, and require both to return
+// the same value and the same error. Negative zero has no big.Int
+// counterpart, so values are compared with it folded into zero.
+
+func toLegacy(f128 Fixed128) fixed128legacy.Fixed128 {
+	return fixed128legacy.FromParts(f128.hi, f128.lo, f128.neg)
+}
+
+// checkLegacy compares a result and its error against the legacy ones.
+// Errors are matched by message, since the two packages declare their own.
+func checkLegacy(t *testing.T, op string, got Fixed128, err error, want fixed128legacy.Fixed128, werr error) {
+	t.Helper()
+
+	checkError(t, op, err, werr)
+	if err == nil && toLegacy(got).Cmp(want) != 0 {
+		t.Fatalf("%s = %v, want %v", op, got, want)
+	}
+}
+
+func checkError(t *testing.T, op string, err, werr error) {
+	t.Helper()
+
+	if fmt.Sprint(err) != fmt.Sprint(werr) {
+		t.Fatalf("%s error = %v, want %v", op, err, werr)
+	}
+}
+
+func checkString(t *testing.T, op, got, want string) {
+	t.Helper()
+
+	if got != want {
+		t.Fatalf("%s = %q, want %q", op, got, want)
+	}
+}
+
+func addSeeds(f *testing.F) {
+	f.Add(uint64(0), uint64(0), false, uint64(1), uint64(0), false)
+	f.Add(uint64(3), uint64(1<<63), true, uint64(0), uint64(1<<62), false)
+	f.Add(uint64(1<<42), uint64(12345), false, uint64(86400), uint64(0), true)
+	f.Add(^uint64(0), ^uint64(0), false, uint64(1), uint64(1), false)
+	f.Add(^uint64(0), ^uint64(0), true, ^uint64(0), ^uint64(0), false)
+	f.Add(uint64(1<<32), uint64(0), true, uint64(0), uint64(1<<32), true)
+	f.Add(uint64(0), uint64(1), false, uint64(0), uint64(0), false)
+	f.Add(uint64(0), uint64(0), false, uint64(0), uint64(0), true)
+	f.Add(uint64(7), uint64(1<<63), false, uint64(7), uint64(1<<63), true)
+}
+
+func FuzzDifferentialBinary(f *testing.F) {
+	addSeeds(f)
+
+	f.Fuzz(func(t *testing.T, ahi, alo uint64, aneg bool, bhi, blo uint64, bneg bool) {
+		a, b := FromParts(ahi, alo, aneg), FromParts(bhi, blo, bneg)
+		la, lb := toLegacy(a), toLegacy(b)
+
+		got, err := a.Add(b)
+		want, werr := la.Add(lb)
+		checkLegacy(t, "Add", got, err, want, werr)
+
+		got, err = a.Sub(b)
+		want, werr = la.Sub(lb)
+		checkLegacy(t, "Sub", got, err, want, werr)
+
+		got, err = a.Mul(b)
+		want, werr = la.Mul(lb)
+		checkLegacy(t, "Mul", got, err, want, werr)
+
+		got, err = a.Quo(b)
+		want, werr = la.Quo(lb)
+		checkLegacy(t, "Quo", got, err, want, werr)
+
+		got, err = a.Rem(b)
+		want, werr = la.Rem(lb)
+		checkLegacy(t, "Rem", got, err, want, werr)
+
+		checkLegacy(t, "AddSaturating", a.AddSaturating(b), nil, la.AddSaturating(lb), nil)
+		checkLegacy(t, "SubSaturating", a.SubSaturating(b), nil, la.SubSaturating(lb), nil)
+		checkLegacy(t, "MulSaturating", a.MulSaturating(b), nil, la.MulSaturating(lb), nil)
+		checkLegacy(t, "AddWrapping", a.AddWrapping(b), nil, la.AddWrapping(lb), nil)
+		checkLegacy(t, "SubWrapping", a.SubWrapping(b), nil, la.SubWrapping(lb), nil)
+		checkLegacy(t, "MulWrapping", a.MulWrapping(b), nil, la.MulWrapping(lb), nil)
+
+		checkLegacy(t, "Min", a.Min(b), nil, la.Min(lb), nil)
+		checkLegacy(t, "Max", a.Max(b), nil, la.Max(lb), nil)
+
+		// Cmp treats negative zero as zero
+		if got, want := a.Cmp(b), la.Cmp(lb); got != want {
+			t.Fatalf("Cmp(%v, %v) = %d, want %d", a, b, got, want)
+		}
+	})
+}
+
+func FuzzDifferentialUnary(f *testing.F) {
+	f.Add(uint64(2), uint64(3<<61), true, uint(0), 3)
+	f.Add(^uint64(0), ^uint64(0), false, uint(63), 2)
+	f.Add(uint64(0), uint64(1<<63), true, uint(1), 4)
+	f.Add(uint64(0), uint64(0), true, uint(64), 0)
+	f.Add(uint64(1<<20), uint64(0xfedcba9876543210), false, uint(129), 1)
+
+	f.Fuzz(func(t *testing.T, hi, lo uint64, neg bool, n uint, mode int) {
+		// Negative zero prints with a sign that big.Int cannot carry
+		a := signed(hi, lo, neg)
+		la := toLegacy(a)
+		m := RoundingMode(mode % 5)
+		if m < 0 {
+			m = -m
+		}
+		n %= 140
+
+		checkLegacy(t, "Negate", a.Negate(), nil, la.Negate(), nil)
+		checkLegacy(t, "Abs", a.Abs(), nil, la.Abs(), nil)
+		checkLegacy(t, "Trunc", a.Trunc(), nil, la.Trunc(), nil)
+		checkLegacy(t, "Frac", a.Frac(), nil, la.Frac(), nil)
+		checkLegacy(t, "Rsh", a.Rsh(n), nil, la.Rsh(n), nil)
+
+		got, err := a.Floor()
+		want, werr := la.Floor()
+		checkLegacy(t, "Floor", got, err, want, werr)
+
+		got, err = a.Ceil()
+		want, werr = la.Ceil()
+		checkLegacy(t, "Ceil", got, err, want, werr)
+
+		got, err = a.Round(n%70, m)
+		want, werr = la.Round(n%70, fixed128legacy.RoundingMode(m))
+		checkLegacy(t, "Round", got, err, want, werr)
+
+		got, err = a.Lsh(n)
+		want, werr = la.Lsh(n)
+		checkLegacy(t, "Lsh", got, err, want, werr)
+
+		got, err = a.Sqrt()
+		want, werr = la.Sqrt()
+		checkLegacy(t, "Sqrt", got, err, want, werr)
+
+		if gotHi, gotLo, gotNeg := a.Parts(); fixed128legacy.FromParts(gotHi, gotLo, gotNeg).Cmp(la) != 0 {
+			t.Fatalf("Parts(%v) = %x, %x, %t", a, gotHi, gotLo, gotNeg)
+		}
+		if a.IsZero() != la.IsZero() || a.Sign() != la.IsNeg() {
+			t.Fatalf("IsZero, Sign(%v) = %t, %t, want %t, %t", a, a.IsZero(), a.Sign(), la.IsZero(), la.IsNeg())
+		}
+
+		checkString(t, "Bytes", string(a.Bytes()), string(la.Bytes()))
+		bin, _ := a.MarshalBinary()
+		lbin, _ := la.MarshalBinary()
+		checkString(t, "MarshalBinary", string(bin), string(lbin))
+
+		checkString(t, "String", a.String(), la.String())
+		checkString(t, "Base64", a.Base64(), la.Base64())
+		checkString(t, "Base64URL", a.Base64URL(), la.Base64URL())
+		checkString(t, "Decimal", a.Decimal(int(n)-20), la.Decimal(int(n)-20))
+		for _, format := range []string{"%v", "%s", "%x", "%X", "%d", "%f", "%.3f", "%+d", "%-45v", "%045.2f", "%+50x"} {
+			checkString(t, fmt.Sprintf("Sprintf(%q)", format), fmt.Sprintf(format, a), fmt.Sprintf(format, la))
+		}
+
+		if got, want := a.Float64(), la.Float64(); got != want {
+			t.Fatalf("Float64(%v) = %v, want %v", a, got, want)
+		}
+		if got, want := a.ScaledBigInt(), la.ScaledBigInt(); got.Cmp(want) != 0 {
+			t.Fatalf("ScaledBigInt(%v) = %v, want %v", a, got, want)
+		}
+		if got, want := a.BigRat(), la.BigRat(); got.Cmp(want) != 0 {
+			t.Fatalf("BigRat(%v) = %v, want %v", a, got, want)
+		}
+		if got, want := a.BigFloat(), la.BigFloat(); got.Cmp(want) != 0 {
+			t.Fatalf("BigFloat(%v) = %v, want %v", a, got, want)
+		}
+	})
+}
+
+func FuzzDifferentialInt64(f *testing.F) {
+	f.Add(int64(1), int64(3), int64(86400), 3)
+	f.Add(int64(math.MinInt64), int64(-1), int64(1), 0)
+	f.Add(int64(-7), int64(2), int64(math.MaxInt64), 4)
+	f.Add(int64(5), int64(0), int64(5), 1)
+
+	f.Fuzz(func(t *testing.T, x, y, multiplier int64, mode int) {
+		m := RoundingMode(mode % 5)
+		if m < 0 {
+			m = -m
+		}
+		lm := fixed128legacy.RoundingMode(m)
+
+		got, err := ByDivision(x, y)
+		want, werr := fixed128legacy.ByDivision(x, y)
+		checkLegacy(t, "ByDivision", got, err, want, werr)
+
+		got, err = ByDivisionRounded(x, y, m)
+		want, werr = fixed128legacy.ByDivisionRounded(x, y, lm)
+		checkLegacy(t, "ByDivisionRounded", got, err, want, werr)
+		if err != nil {
+			return
+		}
+
+		product, err := got.MulInt64(multiplier)
+		lproduct, werr := want.MulInt64(multiplier)
+		checkError(t, "MulInt64", err, werr)
+		if product != lproduct {
+			t.Fatalf("MulInt64(%v, %d) = %d, want %d", got, multiplier, product, lproduct)
+		}
+
+		product, err = got.MulInt64Rounded(multiplier, m)
+		lproduct, werr = want.MulInt64Rounded(multiplier, lm)
+		checkError(t, "MulInt64Rounded", err, werr)
+		if product != lproduct {
+			t.Fatalf("MulInt64Rounded(%v, %d, %v) = %d, want %d", got, multiplier, m, product, lproduct)
+		}
+	})
+}
+
+func FuzzDifferentialParse(f *testing.F) {
+	for _, seed := range []string{
+		"", "-", "+1", "0.8", "-00000000000000ff.0000000000000001", "1.", ".5",
+		"18446744073709551615.99999999999999999999999", "18446744073709551616",
+		"-3.14159265358979323846264338327950288419716939937510",
+		"AAAAAAAAAAEAAAAAAAAAAA==", "gQAAAAAAAAABAAAAAAAAAAA=", "_-_-_-_-_-_-_-_-_-_-_w==",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		for _, tc := range []struct {
+			op     string
+			parse  func(string) (Fixed128, error)
+			legacy func(string) (fixed128legacy.Fixed128, error)
+		}{
+			{"ParseHex", ParseHex, fixed128legacy.ParseHex},
+			{"ParseDecimal", ParseDecimal, fixed128legacy.ParseDecimal},
+			{"ParseBase64", ParseBase64, fixed128legacy.ParseBase64},
+			{"ParseBase64URL", ParseBase64URL, fixed128legacy.ParseBase64URL},
+		} {
+			got, err := tc.parse(s)
+			want, werr := tc.legacy(s)
+			checkLegacy(t, fmt.Sprintf("%s(%q)", tc.op, s), got, err, want, werr)
+		}
+	})
+}
+
+func FuzzDifferentialFromBytes(f *testing.F) {
+	f.Add([]byte{})
+	f.Add(make([]byte, 16))
+	f.Add(append([]byte{0x81}, make([]byte, 16)...))
+	f.Add(append([]byte{0x02}, make([]byte, 16)...))
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		got, err := FromBytes(b)
+		want, werr := fixed128legacy.FromBytes(b)
+		checkLegacy(t, fmt.Sprintf("FromBytes(%x)", b), got, err, want, werr)
+
+		err = got.UnmarshalBinary(b)
+		werr = want.UnmarshalBinary(b)
+		checkLegacy(t, fmt.Sprintf("UnmarshalBinary(%x)", b), got, err, want, werr)
+	})
+}
+
+func FuzzDifferentialFloat(f *testing.F) {
+	for _, seed := range []float64{0, 1, -0.5, 0x1p-65, 0x1p-64, 0x1.8p-64, 0x1p64, 0x1.fffffffffffffp63, math.NaN(), math.Inf(-1)} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, x float64) {
+		got, err := FromFloat64(x)
+		want, werr := fixed128legacy.FromFloat64(x)
+		checkLegacy(t, fmt.Sprintf("FromFloat64(%v)", x), got, err, want, werr)
+
+		if math.IsNaN(x) {
+			return
+		}
+		bf := big.NewFloat(x)
+		got, err = FromBigFloat(bf)
+		want, werr = fixed128legacy.FromBigFloat(bf)
+		checkLegacy(t, fmt.Sprintf("FromBigFloat(%v)", x), got, err, want, werr)
+
+		r, _ := bf.Rat(nil)
+		if r != nil {
+			got, err = FromBigRat(r)
+			want, werr = fixed128legacy.FromBigRat(r)
+			checkLegacy(t, fmt.Sprintf("FromBigRat(%v)", r), got, err, want, werr)
+		}
+	})
+}
@@ -0,0 +1,173 @@
+package fixed128
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// JSONFormat selects how MarshalJSONFormat encodes a Fixed128.
+// UnmarshalJSON accepts either format.
+type JSONFormat int
+
+const (
+	// JSONString encodes the hexadecimal form returned by String,
+	// such as "-0000000000000001.8000000000000000".
+	JSONString JSONFormat = iota
+	// JSONObject encodes the raw parts as an object, with hi and lo as
+	// 16-digit hexadecimal strings so that no precision is lost to
+	// JSON numbers: {"hi":"0000000000000001","lo":"8000000000000000","neg":true}.
+	JSONObject
+)
+
+var (
+	_ encoding.TextAppender    = Fixed128{}
+	_ encoding.TextMarshaler   = Fixed128{}
+	_ encoding.TextUnmarshaler = (*Fixed128)(nil)
+	_ json.Marshaler           = Fixed128{}
+	_ json.Unmarshaler         = (*Fixed128)(nil)
+	_ json.Marshaler           = Fixed128Object{}
+	_ json.Unmarshaler         = (*Fixed128Object)(nil)
+	_ sql.Scanner              = (*Fixed128)(nil)
+	_ driver.Valuer            = Fixed128{}
+)
+
+type jsonObject struct {
+	Hi  string `json:"hi"`
+	Lo  string `json:"lo"`
+	Neg bool   `json:"neg"`
+}
+
+// AppendText appends the hexadecimal form returned by String to b.
+func (f128 Fixed128) AppendText(b []byte) ([]byte, error) {
+	return f128.appendHex(b, false), nil
+}
+
+func (f128 Fixed128) MarshalText() ([]byte, error) {
+	return f128.AppendText(nil)
+}
+
+// UnmarshalText parses the hexadecimal form accepted by ParseHex.
+func (f128 *Fixed128) UnmarshalText(text []byte) error {
+	value, err := ParseHex(string(text))
+	if err != nil {
+		return err
+	}
+	*f128 = value
+	return nil
+}
+
+// MarshalJSON encodes the Fixed128 as JSONString. Convert it to
+// Fixed128Object for the object form.
+func (f128 Fixed128) MarshalJSON() ([]byte, error) {
+	return f128.MarshalJSONFormat(JSONString)
+}
+
+// MarshalJSONFormat encodes the Fixed128 in the given format.
+func (f128 Fixed128) MarshalJSONFormat(format JSONFormat) ([]byte, error) {
+	switch format {
+	case JSONString:
+		b := append([]byte{'"'}, f128.appendHex(nil, false)...)
+		return append(b, '"'), nil
+	case JSONObject:
+		return json.Marshal(jsonObject{
+			Hi:  fmt.Sprintf("%016x", f128.hi),
+			Lo:  fmt.Sprintf("%016x", f128.lo),
+			Neg: f128.neg,
+		})
+	default:
+		return nil, fmt.Errorf("%w: unknown JSON format %d", ErrorInvalidFormat, format)
+	}
+}
+
+// UnmarshalJSON decodes either JSON format. Like the standard library
+// decoders, it leaves the value unchanged when given null.
+func (f128 *Fixed128) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		return nil
+	case len(data) > 0 && data[0] == '"':
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return fmt.Errorf("%w: %s", ErrorInvalidFormat, data)
+		}
+		return f128.UnmarshalText([]byte(s))
+	case len(data) > 0 && data[0] == '{':
+		var obj jsonObject
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return fmt.Errorf("%w: %s", ErrorInvalidFormat, data)
+		}
+		hi, err := strconv.ParseUint(obj.Hi, 16, 64)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrorInvalidFormat, data)
+		}
+		lo, err := strconv.ParseUint(obj.Lo, 16, 64)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrorInvalidFormat, data)
+		}
+		*f128 = signed(hi, lo, obj.Neg)
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", ErrorInvalidFormat, data)
+	}
+}
+
+// Fixed128Object is a Fixed128 encoded in JSON as JSONObject.
+// It decodes either format.
+type Fixed128Object Fixed128
+
+func (f Fixed128Object) MarshalJSON() ([]byte, error) {
+	return Fixed128(f).MarshalJSONFormat(JSONObject)
+}
+
+func (f *Fixed128Object) UnmarshalJSON(data []byte) error {
+	return (*Fixed128)(f).UnmarshalJSON(data)
+}
+
+// IsBinary reports whether b holds one of the binary layouts rather than
+// text, for drivers that return both BLOB and TEXT columns as []byte. It
+// must be 16 or 17 bytes long and not all printable ASCII, as text is. The
+// version byte of the 17-byte layout is never printable, and the 16-byte
+// layout can only be all printable for values of at least 2^61.
+func IsBinary(b []byte) bool {
+	if len(b) != 16 && len(b) != 17 {
+		return false
+	}
+	for _, c := range b {
+		if c < ' ' || c > '~' {
+			return true
+		}
+	}
+	return false
+}
+
+// Scan implements sql.Scanner. A []byte that IsBinary is decoded as a
+// binary layout, as stored by Value or taken from Bytes. Any other []byte,
+// or a string, is parsed as the hexadecimal text form. NULL is an error;
+// scan into a *Fixed128 pointer or sql.Null[Fixed128] for nullable columns.
+func (f128 *Fixed128) Scan(src any) error {
+	switch src := src.(type) {
+	case []byte:
+		if IsBinary(src) {
+			return f128.UnmarshalBinary(src)
+		}
+		return f128.UnmarshalText(src)
+	case string:
+		return f128.UnmarshalText([]byte(src))
+	case nil:
+		return fmt.Errorf("%w: cannot scan NULL into Fixed128", ErrorInvalidFormat)
+	default:
+		return fmt.Errorf("%w: cannot scan %T into Fixed128", ErrorInvalidFormat, src)
+	}
+}
+
+// Value implements driver.Valuer, storing the signed 17-byte version 1
+// binary layout so that negative values survive a round trip.
+func (f128 Fixed128) Value() (driver.Value, error) {
+	return f128.MarshalBinary()
+}
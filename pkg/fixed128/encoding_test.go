@@ -0,0 +1,146 @@
+package fixed128
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	f128 := Fixed128{hi: 1, lo: 1 << 63, neg: true}
+	tt := []struct {
+		format JSONFormat
+		want   string
+	}{
+		{JSONString, `"-0000000000000001.8000000000000000"`},
+		{JSONObject, `{"hi":"0000000000000001","lo":"8000000000000000","neg":true}`},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.want, func(t *testing.T) {
+			got, err := f128.MarshalJSONFormat(tc.format)
+			if err != nil {
+				t.Fatalf("MarshalJSONFormat() error = %v", err)
+			}
+			if string(got) != tc.want {
+				t.Errorf("MarshalJSONFormat() = %s, want %s", got, tc.want)
+			}
+		})
+	}
+
+	// Struct fields pick the format by type
+	got, err := json.Marshal(struct {
+		S Fixed128       `json:"s"`
+		O Fixed128Object `json:"o"`
+	}{f128, Fixed128Object(f128)})
+	if want := `{"s":"-0000000000000001.8000000000000000","o":{"hi":"0000000000000001","lo":"8000000000000000","neg":true}}`; err != nil || string(got) != want {
+		t.Errorf("Marshal() = %s, %v, want %s", got, err, want)
+	}
+	var back Fixed128Object
+	if err := json.Unmarshal([]byte(`"-1.8"`), &back); err != nil || Fixed128(back) != f128 {
+		t.Errorf("Unmarshal() into Fixed128Object = %v, %v, want %v", Fixed128(back), err, f128)
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	tt := []struct {
+		json string
+		want Fixed128
+		err  error
+	}{
+		{`"-0000000000000001.8000000000000000"`, Fixed128{hi: 1, lo: 1 << 63, neg: true}, nil},
+		{`"ABC.DEF"`, Fixed128{hi: 0xabc, lo: 0xdef << 52}, nil},
+		{`{"hi":"0000000000000001","lo":"8000000000000000","neg":true}`, Fixed128{hi: 1, lo: 1 << 63, neg: true}, nil},
+		{` {"hi":"0", "lo":"1"} `, Fixed128{lo: 1}, nil},
+		{`{"lo":"1"}`, Fixed128{hi: 42}, ErrorInvalidFormat},
+		{`{"hi":"0","lo":"0","neg":true}`, Fixed128{}, nil},
+		{`null`, Fixed128{hi: 42}, nil},
+		{`1.5`, Fixed128{hi: 42}, ErrorInvalidFormat},
+		{`"1.5.0"`, Fixed128{hi: 42}, ErrorInvalidFormat},
+		{`{"hi":"xyz"}`, Fixed128{hi: 42}, ErrorInvalidFormat},
+		{`{"hi":1}`, Fixed128{hi: 42}, ErrorInvalidFormat},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.json, func(t *testing.T) {
+			got := Fixed128{hi: 42}
+			err := got.UnmarshalJSON([]byte(tc.json))
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("UnmarshalJSON(%s) error = %v, want %v", tc.json, err, tc.err)
+			}
+			if got != tc.want {
+				t.Errorf("UnmarshalJSON(%s) = %v, want %v", tc.json, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestScan(t *testing.T) {
+	legacy := []byte{0, 0, 0, 0, 0, 0, 0, 0x02, 0x40, 0, 0, 0, 0, 0, 0, 0}
+
+	tt := []struct {
+		name string
+		src  any
+		want Fixed128
+		err  error
+	}{
+		{"legacy blob", legacy, Fixed128{hi: 2, lo: 1 << 62}, nil},
+		{"version 1 blob", append([]byte{0x81}, legacy...), Fixed128{hi: 2, lo: 1 << 62, neg: true}, nil},
+		{"text bytes", []byte("-2.4"), Fixed128{hi: 2, lo: 1 << 62, neg: true}, nil},
+		{"text string", "0000000000000002.4000000000000000", Fixed128{hi: 2, lo: 1 << 62}, nil},
+		{"16-byte text", []byte("1234567890ab.cde"), Fixed128{hi: 0x1234567890ab, lo: 0xcde << 52}, nil},
+		{"17-byte text", []byte("-1234567890ab.cde"), Fixed128{hi: 0x1234567890ab, lo: 0xcde << 52, neg: true}, nil},
+		{"bad text", "two", Fixed128{}, ErrorInvalidFormat},
+		{"bad 16-byte text", []byte("1234567890ab.cdg"), Fixed128{}, ErrorInvalidFormat},
+		{"bad version", append([]byte{0x02}, legacy...), Fixed128{}, ErrorUnknownVersion},
+		{"null", nil, Fixed128{}, ErrorInvalidFormat},
+		{"integer", int64(2), Fixed128{}, ErrorInvalidFormat},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var got Fixed128
+			err := got.Scan(tc.src)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("Scan(%v) error = %v, want %v", tc.src, err, tc.err)
+			}
+			if got != tc.want {
+				t.Errorf("Scan(%v) = %v, want %v", tc.src, got, tc.want)
+			}
+		})
+	}
+}
+
+func FuzzEncodingRoundTrip(f *testing.F) {
+	f.Add(uint64(0), uint64(0), false)
+	f.Add(uint64(1), uint64(1<<63), true)
+	f.Add(^uint64(0), ^uint64(0), false)
+
+	f.Fuzz(func(t *testing.T, hi, lo uint64, neg bool) {
+		f128 := signed(hi, lo, neg)
+
+		text, _ := f128.MarshalText()
+		var fromText Fixed128
+		if err := fromText.UnmarshalText(text); err != nil || fromText != f128 {
+			t.Fatalf("UnmarshalText(%s) = %v, %v, want %v", text, fromText, err, f128)
+		}
+
+		for _, format := range []JSONFormat{JSONString, JSONObject} {
+			data, err := f128.MarshalJSONFormat(format)
+			if err != nil {
+				t.Fatalf("Marshal(%v) error = %v", f128, err)
+			}
+
+			var fromJSON Fixed128
+			if err := json.Unmarshal(data, &fromJSON); err != nil || fromJSON != f128 {
+				t.Fatalf("Unmarshal(%s) = %v, %v, want %v", data, fromJSON, err, f128)
+			}
+		}
+
+		value, _ := f128.Value()
+		var scanned Fixed128
+		if err := scanned.Scan(value); err != nil || scanned != f128 {
+			t.Fatalf("Scan(%x) = %v, %v, want %v", value, scanned, err, f128)
+		}
+	})
+}
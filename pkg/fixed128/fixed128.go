@@ -1,11 +1,19 @@
 package fixed128
 
-import "encoding/binary"
+import (
+	"encoding"
+	"encoding/binary"
+)
 
 var (
 	Zero        = Fixed128{}
 	One         = Fixed128{hi: 1}
 	NegativeOne = Fixed128{hi: 1, neg: true}
+
+	// Max and Min are the largest and smallest representable values,
+	// just short of 2^64 and -2^64.
+	Max = Fixed128{hi: ^uint64(0), lo: ^uint64(0)}
+	Min = Fixed128{hi: ^uint64(0), lo: ^uint64(0), neg: true}
 )
 
 // Fixed128 represents a 128-bit fixed-point fractional number.
@@ -26,10 +34,16 @@ func FromParts(hi, lo uint64, neg bool) Fixed128 {
 	return Fixed128{hi: hi, lo: lo, neg: neg}
 }
 
-// ByDivision divides two int64 numbers and returns the result as a Fixed128.
-// It returns an error if the divisor is zero.
+// ByDivision divides two int64 numbers and returns the result as a Fixed128,
+// truncated toward zero. It returns an error if the divisor is zero.
 func ByDivision(x, y int64) (Fixed128, error) {
-	return divide(x, y)
+	return divide(x, y, RoundTruncate)
+}
+
+// ByDivisionRounded divides two int64 numbers and returns the result as a
+// Fixed128, rounded according to mode. It returns an error if the divisor is zero.
+func ByDivisionRounded(x, y int64, mode RoundingMode) (Fixed128, error) {
+	return divide(x, y, mode)
 }
 
 func MustByDivision(x, y int64) Fixed128 {
@@ -40,8 +54,9 @@ func MustByDivision(x, y int64) Fixed128 {
 	return f128
 }
 
+// IsZero reports whether the number is zero, of either sign.
 func (f128 Fixed128) IsZero() bool {
-	return f128 == Zero
+	return f128.hi == 0 && f128.lo == 0
 }
 
 // Parts returns the constituent parts of the Fixed128 number:
@@ -94,6 +109,35 @@ func (f128 Fixed128) Sub(other Fixed128) (Fixed128, error) {
 	return f128.Add(other.Negate())
 }
 
+// AddSaturating adds two Fixed128 numbers, clamping the result
+// to Max or Min instead of overflowing.
+func (f128 Fixed128) AddSaturating(other Fixed128) Fixed128 {
+	result, err := f128.Add(other)
+	if err != nil {
+		// Only same-signed values can overflow
+		return saturate(f128.neg)
+	}
+	return result
+}
+
+// SubSaturating subtracts another Fixed128 number, clamping the result
+// to Max or Min instead of overflowing.
+func (f128 Fixed128) SubSaturating(other Fixed128) Fixed128 {
+	return f128.AddSaturating(other.Negate())
+}
+
+// AddWrapping adds two Fixed128 numbers, reducing the magnitude of the
+// result modulo 2^128 while keeping its sign.
+func (f128 Fixed128) AddWrapping(other Fixed128) Fixed128 {
+	return addWrapping(f128, other)
+}
+
+// SubWrapping subtracts another Fixed128 number, reducing the magnitude
+// of the result modulo 2^128 while keeping its sign.
+func (f128 Fixed128) SubWrapping(other Fixed128) Fixed128 {
+	return addWrapping(f128, other.Negate())
+}
+
 func (f128 Fixed128) Negate() Fixed128 {
 	return Fixed128{
 		hi:  f128.hi,
@@ -102,7 +146,12 @@ func (f128 Fixed128) Negate() Fixed128 {
 	}
 }
 
+// Cmp compares two Fixed128 numbers, returning -1, 0 or 1.
+// Negative zero compares equal to zero.
 func (f128 Fixed128) Cmp(other Fixed128) int {
+	if f128.IsZero() && other.IsZero() {
+		return 0
+	}
 	if f128.neg && !other.neg {
 		return -1
 	}
@@ -117,6 +166,31 @@ func (f128 Fixed128) Cmp(other Fixed128) int {
 	return cmpResult
 }
 
+// Fixed128 values have two binary layouts.
+//
+// The legacy layout, returned by Bytes, is 16 bytes long: the whole part
+// followed by the fractional part, both big-endian. It has no room for the
+// sign, so it is only suitable for non-negative values such as dates.
+//
+// The version 1 layout, returned by MarshalBinary, is 17 bytes long:
+//
+//	byte  0     tag: the version (1) in the low seven bits, the sign in the high bit
+//	bytes 1-8   whole part, big-endian
+//	bytes 9-16  fractional part, big-endian
+//
+// FromBytes and UnmarshalBinary accept both layouts, telling them apart by length.
+const (
+	binaryVersion1 = 0x01
+	binarySignBit  = 0x80
+)
+
+var (
+	_ encoding.BinaryAppender    = Fixed128{}
+	_ encoding.BinaryMarshaler   = Fixed128{}
+	_ encoding.BinaryUnmarshaler = (*Fixed128)(nil)
+)
+
+// Bytes returns the legacy 16-byte layout, dropping the sign.
 func (f128 Fixed128) Bytes() []byte {
 	b := make([]byte, 16)
 	binary.BigEndian.PutUint64(b[:8], f128.hi)
@@ -124,9 +198,19 @@ func (f128 Fixed128) Bytes() []byte {
 	return b
 }
 
+// FromBytes decodes either the legacy 16-byte layout, which is always
+// non-negative, or the signed 17-byte version 1 layout.
 func FromBytes(b []byte) (Fixed128, error) {
 	f128 := Fixed128{}
-	if len(b) != 16 {
+	switch len(b) {
+	case 16:
+	case 17:
+		if b[0]&^binarySignBit != binaryVersion1 {
+			return f128, ErrorUnknownVersion
+		}
+		f128.neg = b[0]&binarySignBit != 0
+		b = b[1:]
+	default:
 		return f128, ErrorBadByteLength
 	}
 	f128.hi = binary.BigEndian.Uint64(b[:8])
@@ -134,12 +218,155 @@ func FromBytes(b []byte) (Fixed128, error) {
 	return f128, nil
 }
 
+// AppendBinary appends the signed version 1 layout to b.
+func (f128 Fixed128) AppendBinary(b []byte) ([]byte, error) {
+	tag := byte(binaryVersion1)
+	if f128.neg {
+		tag |= binarySignBit
+	}
+	b = append(b, tag)
+	b = binary.BigEndian.AppendUint64(b, f128.hi)
+	b = binary.BigEndian.AppendUint64(b, f128.lo)
+	return b, nil
+}
+
+// MarshalBinary returns the signed version 1 layout.
+func (f128 Fixed128) MarshalBinary() ([]byte, error) {
+	return f128.AppendBinary(make([]byte, 0, 17))
+}
+
+// UnmarshalBinary decodes either binary layout, as FromBytes does.
+func (f128 *Fixed128) UnmarshalBinary(data []byte) error {
+	value, err := FromBytes(data)
+	if err != nil {
+		return err
+	}
+	*f128 = value
+	return nil
+}
+
 func (f128 Fixed128) Sign() bool {
 	return f128.neg
 }
 
-// MulInt64 multiplies the Fixed128 by an int64 and returns the result as int64.
-// It returns an error if the multiplication would overflow.
+// MulInt64 multiplies the Fixed128 by an int64 and returns the result as int64,
+// truncated toward zero. It returns ErrorAdditionOverflow if the
+// multiplication would overflow.
 func (f128 Fixed128) MulInt64(multiplier int64) (int64, error) {
-	return mulInt64(f128, multiplier)
+	return mulInt64(f128, multiplier, RoundTruncate)
+}
+
+// MulInt64Rounded multiplies the Fixed128 by an int64 and returns the result
+// as int64, rounded according to mode. Like MulInt64, it returns
+// ErrorAdditionOverflow if the multiplication would overflow.
+func (f128 Fixed128) MulInt64Rounded(multiplier int64, mode RoundingMode) (int64, error) {
+	return mulInt64(f128, multiplier, mode)
+}
+
+// Mul multiplies two Fixed128 numbers and returns the result,
+// truncated toward zero. It returns an error if the product
+// does not fit in 128 bits.
+func (f128 Fixed128) Mul(other Fixed128) (Fixed128, error) {
+	return mul(f128, other)
+}
+
+// MulSaturating multiplies two Fixed128 numbers, truncated toward zero,
+// clamping the result to Max or Min instead of overflowing.
+func (f128 Fixed128) MulSaturating(other Fixed128) Fixed128 {
+	result, err := mul(f128, other)
+	if err != nil {
+		return saturate(f128.neg != other.neg)
+	}
+	return result
+}
+
+// MulWrapping multiplies two Fixed128 numbers, truncated toward zero,
+// reducing the magnitude of the result modulo 2^128 while keeping its sign.
+func (f128 Fixed128) MulWrapping(other Fixed128) Fixed128 {
+	p := mulWords(f128.hi, f128.lo, other.hi, other.lo)
+	return signed(p[2], p[1], f128.neg != other.neg)
+}
+
+// Quo divides the Fixed128 by another and returns the quotient,
+// truncated toward zero. It returns an error if the divisor is zero
+// or the quotient does not fit in 128 bits.
+func (f128 Fixed128) Quo(other Fixed128) (Fixed128, error) {
+	return quo(f128, other)
+}
+
+// Rem returns the remainder of dividing the Fixed128 by another,
+// f128 - other*n where n is the integer quotient truncated toward zero.
+// The result has the sign of f128, matching Go's % operator.
+// It returns an error if the divisor is zero.
+func (f128 Fixed128) Rem(other Fixed128) (Fixed128, error) {
+	return rem(f128, other)
+}
+
+// Abs returns the absolute value of the Fixed128.
+func (f128 Fixed128) Abs() Fixed128 {
+	return Fixed128{hi: f128.hi, lo: f128.lo}
+}
+
+// Min returns the smaller of the Fixed128 and other.
+func (f128 Fixed128) Min(other Fixed128) Fixed128 {
+	if other.Cmp(f128) < 0 {
+		return other
+	}
+	return f128
+}
+
+// Max returns the larger of the Fixed128 and other.
+func (f128 Fixed128) Max(other Fixed128) Fixed128 {
+	if other.Cmp(f128) > 0 {
+		return other
+	}
+	return f128
+}
+
+// Round rounds the Fixed128 to fracBits fractional bits according to mode.
+// A fracBits of 0 rounds to a whole number, and 64 or more is a no-op.
+// It returns an error if rounding away from zero overflows.
+func (f128 Fixed128) Round(fracBits uint, mode RoundingMode) (Fixed128, error) {
+	return round(f128, fracBits, mode)
+}
+
+// Floor returns the greatest whole number less than or equal to the Fixed128.
+// It returns an error if the result overflows.
+func (f128 Fixed128) Floor() (Fixed128, error) {
+	return round(f128, 0, RoundFloor)
+}
+
+// Ceil returns the least whole number greater than or equal to the Fixed128.
+// It returns an error if the result overflows.
+func (f128 Fixed128) Ceil() (Fixed128, error) {
+	return round(f128, 0, RoundCeil)
+}
+
+// Trunc returns the whole part of the Fixed128, rounded toward zero.
+func (f128 Fixed128) Trunc() Fixed128 {
+	return signed(f128.hi, 0, f128.neg)
+}
+
+// Frac returns the fractional part of the Fixed128, with the same sign,
+// so that f128 == f128.Trunc() + f128.Frac().
+func (f128 Fixed128) Frac() Fixed128 {
+	return signed(0, f128.lo, f128.neg)
+}
+
+// Lsh multiplies the Fixed128 by 2^n.
+// It returns an error if any set bits are shifted out.
+func (f128 Fixed128) Lsh(n uint) (Fixed128, error) {
+	return lsh(f128, n)
+}
+
+// Rsh divides the Fixed128 by 2^n. Like big.Int.Rsh, it rounds toward
+// negative infinity, so shifting a negative number never reaches zero.
+func (f128 Fixed128) Rsh(n uint) Fixed128 {
+	return rsh(f128, n)
+}
+
+// Sqrt returns the square root of the Fixed128, rounded to nearest.
+// It returns an error if the number is negative.
+func (f128 Fixed128) Sqrt() (Fixed128, error) {
+	return sqrt(f128)
 }
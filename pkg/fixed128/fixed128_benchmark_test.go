@@ -35,3 +35,51 @@ func BenchmarkSub(b *testing.B) {
 		_, _ = a.Sub(c)
 	}
 }
+
+func BenchmarkMul(b *testing.B) {
+	a := Fixed128{hi: 100, lo: 200, neg: false}
+	c := Fixed128{hi: 50, lo: 100, neg: true}
+	for b.Loop() {
+		_, _ = a.Mul(c)
+	}
+}
+
+func BenchmarkQuo(b *testing.B) {
+	a := Fixed128{hi: 100, lo: 200, neg: false}
+	c := Fixed128{hi: 50, lo: 100, neg: true}
+	for b.Loop() {
+		_, _ = a.Quo(c)
+	}
+}
+
+func BenchmarkInt128Cmp(b *testing.B) {
+	a, _ := ToInt128(Fixed128{hi: 100, lo: 200, neg: false})
+	c, _ := ToInt128(Fixed128{hi: 50, lo: 100, neg: true})
+	for b.Loop() {
+		_ = a.Cmp(c)
+	}
+}
+
+func BenchmarkInt128Add(b *testing.B) {
+	a, _ := ToInt128(Fixed128{hi: 100, lo: 200, neg: false})
+	c, _ := ToInt128(Fixed128{hi: 50, lo: 100, neg: false})
+	for b.Loop() {
+		_, _ = a.Add(c)
+	}
+}
+
+func BenchmarkInt128AddDifferentSigns(b *testing.B) {
+	a, _ := ToInt128(Fixed128{hi: 100, lo: 200, neg: false})
+	c, _ := ToInt128(Fixed128{hi: 50, lo: 100, neg: true})
+	for b.Loop() {
+		_, _ = a.Add(c)
+	}
+}
+
+func BenchmarkInt128Sub(b *testing.B) {
+	a, _ := ToInt128(Fixed128{hi: 100, lo: 200, neg: false})
+	c, _ := ToInt128(Fixed128{hi: 50, lo: 100, neg: false})
+	for b.Loop() {
+		_, _ = a.Sub(c)
+	}
+}
@@ -1,6 +1,10 @@
 package fixed128
 
-import "testing"
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
 
 func TestFixed128_FromParts(t *testing.T) {
 	tt := []struct {
@@ -60,6 +64,63 @@ func TestFixed128_ByDivision(t *testing.T) {
 	}
 }
 
+func TestFixed128_ByDivisionRounded(t *testing.T) {
+	tt := []struct {
+		x, y int64
+		mode RoundingMode
+		want Fixed128
+	}{
+		{1, 3, RoundTruncate, Fixed128{lo: 0x5555555555555555}},
+		{1, 3, RoundFloor, Fixed128{lo: 0x5555555555555555}},
+		{1, 3, RoundCeil, Fixed128{lo: 0x5555555555555556}},
+		{1, 3, RoundHalfEven, Fixed128{lo: 0x5555555555555555}},
+		{1, 3, RoundHalfAwayFromZero, Fixed128{lo: 0x5555555555555555}},
+		{-1, 3, RoundFloor, Fixed128{lo: 0x5555555555555556, neg: true}},
+		{-1, 3, RoundCeil, Fixed128{lo: 0x5555555555555555, neg: true}},
+		{2, 3, RoundTruncate, Fixed128{lo: 0xaaaaaaaaaaaaaaaa}},
+		{2, 3, RoundHalfEven, Fixed128{lo: 0xaaaaaaaaaaaaaaab}},
+		{-2, 3, RoundHalfAwayFromZero, Fixed128{lo: 0xaaaaaaaaaaaaaaab, neg: true}},
+		{10, 4, RoundCeil, Fixed128{hi: 2, lo: 1 << 63}},
+		{0, -3, RoundFloor, Fixed128{}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.mode.String(), func(t *testing.T) {
+			got, err := ByDivisionRounded(tc.x, tc.y, tc.mode)
+			if err != nil {
+				t.Fatalf("ByDivisionRounded(%d, %d, %v) returned error: %v", tc.x, tc.y, tc.mode, err)
+			}
+			if got != tc.want {
+				t.Errorf("ByDivisionRounded(%d, %d, %v) = %+v, want %+v", tc.x, tc.y, tc.mode, got, tc.want)
+			}
+		})
+	}
+}
+
+func FuzzFixed128_ByDivisionRoundTrip(f *testing.F) {
+	f.Add(int64(1), int64(3))
+	f.Add(int64(-1_700_000_000_000_000_000), int64(86_400_000_000_000))
+	f.Add(int64(-1<<63), int64(1))
+	f.Add(int64(1<<63-1), int64(-1<<63))
+
+	f.Fuzz(func(t *testing.T, x, y int64) {
+		if y == 0 {
+			return
+		}
+		f128, err := ByDivisionRounded(x, y, RoundHalfEven)
+		if err != nil {
+			t.Fatalf("ByDivisionRounded(%d, %d) returned error: %v", x, y, err)
+		}
+		got, err := f128.MulInt64Rounded(y, RoundHalfEven)
+		if err != nil {
+			t.Fatalf("MulInt64Rounded(%+v, %d) returned error: %v", f128, y, err)
+		}
+		if got != x {
+			t.Fatalf("ByDivisionRounded(%d, %d).MulInt64Rounded(%d) = %d, want %d", x, y, y, got, x)
+		}
+	})
+}
+
 func FuzzFixed128_FromParts(f *testing.F) {
 	f.Add(uint64(0), uint64(0), false)
 	f.Add(uint64(1), uint64(1), true)
@@ -77,6 +138,91 @@ func FuzzFixed128_FromParts(f *testing.F) {
 	})
 }
 
+func TestFixed128_MarshalBinary(t *testing.T) {
+	tt := []struct {
+		name string
+		f128 Fixed128
+		want []byte
+	}{
+		{"zero", Fixed128{}, []byte{0x01, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}},
+		{"positive", Fixed128{hi: 0x0102, lo: 0x8000000000000003}, []byte{0x01, 0, 0, 0, 0, 0, 0, 0x01, 0x02, 0x80, 0, 0, 0, 0, 0, 0, 0x03}},
+		{"negative", Fixed128{hi: 0x0102, lo: 0x03, neg: true}, []byte{0x81, 0, 0, 0, 0, 0, 0, 0x01, 0x02, 0, 0, 0, 0, 0, 0, 0, 0x03}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.f128.MarshalBinary()
+			if err != nil {
+				t.Fatalf("MarshalBinary() returned error: %v", err)
+			}
+			if !bytes.Equal(got, tc.want) {
+				t.Errorf("MarshalBinary() = %x, want %x", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFixed128_FromBytes(t *testing.T) {
+	legacy := []byte{0, 0, 0, 0, 0, 0, 0x01, 0x02, 0x80, 0, 0, 0, 0, 0, 0, 0x03}
+
+	tt := []struct {
+		name string
+		b    []byte
+		want Fixed128
+		err  error
+	}{
+		{"legacy", legacy, Fixed128{hi: 0x0102, lo: 0x8000000000000003}, nil},
+		{"version 1", append([]byte{0x01}, legacy...), Fixed128{hi: 0x0102, lo: 0x8000000000000003}, nil},
+		{"version 1 negative", append([]byte{0x81}, legacy...), Fixed128{hi: 0x0102, lo: 0x8000000000000003, neg: true}, nil},
+		{"unknown version", append([]byte{0x02}, legacy...), Fixed128{}, ErrorUnknownVersion},
+		{"short", legacy[:15], Fixed128{}, ErrorBadByteLength},
+		{"long", append(legacy, 0, 0), Fixed128{}, ErrorBadByteLength},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := FromBytes(tc.b)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("FromBytes(%x) error = %v, want %v", tc.b, err, tc.err)
+			}
+			if got != tc.want {
+				t.Errorf("FromBytes(%x) = %+v, want %+v", tc.b, got, tc.want)
+			}
+		})
+	}
+}
+
+func FuzzFixed128_BinaryRoundTrip(f *testing.F) {
+	f.Add(uint64(0), uint64(0), false)
+	f.Add(uint64(0), uint64(0), true)
+	f.Add(uint64(1<<42), uint64(1<<63), false)
+	f.Add(^uint64(0), ^uint64(0), true)
+
+	f.Fuzz(func(t *testing.T, hi, lo uint64, neg bool) {
+		f128 := FromParts(hi, lo, neg)
+		b, err := f128.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary() returned error: %v", err)
+		}
+
+		var got Fixed128
+		if err := got.UnmarshalBinary(b); err != nil {
+			t.Fatalf("UnmarshalBinary(%x) returned error: %v", b, err)
+		}
+		if got != f128 {
+			t.Fatalf("UnmarshalBinary(%x) = %+v, want %+v", b, got, f128)
+		}
+
+		legacy, err := FromBytes(f128.Bytes())
+		if err != nil {
+			t.Fatalf("FromBytes(%x) returned error: %v", f128.Bytes(), err)
+		}
+		if legacy != FromParts(hi, lo, false) {
+			t.Fatalf("FromBytes(%x) = %+v, want magnitude of %+v", f128.Bytes(), legacy, f128)
+		}
+	})
+}
+
 func TestAdd(t *testing.T) {
 	tt := []struct {
 		name string
@@ -138,6 +284,10 @@ func TestSub(t *testing.T) {
 
 		// With lo parts
 		{"with lo parts", Fixed128{hi: 5, lo: 100}, Fixed128{hi: 2, lo: 50}, Fixed128{hi: 3, lo: 50}, false},
+
+		// Large magnitudes
+		{"positive small - positive large", Fixed128{hi: 1}, Fixed128{hi: ^uint64(0)}, Fixed128{hi: ^uint64(1), neg: true}, false},
+		{"same sign, small hi - large hi", Fixed128{hi: 1, neg: true}, Fixed128{hi: ^uint64(0), neg: true}, Fixed128{hi: ^uint64(1)}, false},
 	}
 
 	for _, tc := range tt {
@@ -181,8 +331,8 @@ func TestSubUnderflow(t *testing.T) {
 		a    Fixed128
 		b    Fixed128
 	}{
-		{"positive small - positive large", Fixed128{hi: 1}, Fixed128{hi: ^uint64(0)}},
-		{"same sign, small hi - large hi", Fixed128{hi: 1, neg: true}, Fixed128{hi: ^uint64(0), neg: true}},
+		{"positive max - negative", Fixed128{hi: ^uint64(0), lo: ^uint64(0)}, Fixed128{lo: 1, neg: true}},
+		{"negative max - positive", Fixed128{hi: ^uint64(0), neg: true}, Fixed128{hi: 1}},
 	}
 
 	for _, tc := range tt {
@@ -195,6 +345,62 @@ func TestSubUnderflow(t *testing.T) {
 	}
 }
 
+func TestSaturating(t *testing.T) {
+	half := Fixed128{hi: 1 << 63}
+
+	tt := []struct {
+		name string
+		op   func(Fixed128, Fixed128) Fixed128
+		a    Fixed128
+		b    Fixed128
+		want Fixed128
+	}{
+		{"add in range", Fixed128.AddSaturating, One, One, Fixed128{hi: 2}},
+		{"add positive overflow", Fixed128.AddSaturating, Max, Fixed128{lo: 1}, Max},
+		{"add negative overflow", Fixed128.AddSaturating, Min, NegativeOne, Min},
+		{"sub positive overflow", Fixed128.SubSaturating, Max, NegativeOne, Max},
+		{"sub negative overflow", Fixed128.SubSaturating, Min, One, Min},
+		{"sub in range", Fixed128.SubSaturating, Min, Min, Zero},
+		{"mul in range", Fixed128.MulSaturating, half, Fixed128{lo: 1 << 63}, Fixed128{hi: 1 << 62}},
+		{"mul positive overflow", Fixed128.MulSaturating, half.Negate(), Fixed128{hi: 2, neg: true}, Max},
+		{"mul negative overflow", Fixed128.MulSaturating, half, Fixed128{hi: 2, neg: true}, Min},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.op(tc.a, tc.b); got != tc.want {
+				t.Errorf("%v, %v = %v, want %v", tc.a, tc.b, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestWrapping(t *testing.T) {
+	tt := []struct {
+		name string
+		op   func(Fixed128, Fixed128) Fixed128
+		a    Fixed128
+		b    Fixed128
+		want Fixed128
+	}{
+		{"add in range", Fixed128.AddWrapping, One, NegativeOne, Zero},
+		{"add positive overflow", Fixed128.AddWrapping, Max, Fixed128{hi: 1, lo: 1}, Fixed128{hi: 1}},
+		{"add negative overflow", Fixed128.AddWrapping, Min, Fixed128{hi: 2, neg: true}, Fixed128{hi: 1, lo: ^uint64(0), neg: true}},
+		{"add wraps to zero", Fixed128.AddWrapping, Max, Fixed128{lo: 1}, Zero},
+		{"sub positive overflow", Fixed128.SubWrapping, Max, Fixed128{hi: 1, neg: true}, Fixed128{lo: ^uint64(0)}},
+		{"mul overflow", Fixed128.MulWrapping, Fixed128{hi: 1<<63 + 3}, Fixed128{hi: 2, neg: true}, Fixed128{hi: 6, neg: true}},
+		{"mul in range", Fixed128.MulWrapping, Fixed128{hi: 3}, Fixed128{lo: 1 << 63}, Fixed128{hi: 1, lo: 1 << 63}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.op(tc.a, tc.b); got != tc.want {
+				t.Errorf("%v, %v = %v, want %v", tc.a, tc.b, got, tc.want)
+			}
+		})
+	}
+}
+
 func TestMulInt64(t *testing.T) {
 	tt := []struct {
 		name       string
@@ -227,6 +433,46 @@ func TestMulInt64(t *testing.T) {
 	}
 }
 
+func TestMulInt64Rounded(t *testing.T) {
+	oneAndHalf := Fixed128{hi: 1, lo: 1 << 63}
+	twoAndHalf := Fixed128{hi: 2, lo: 1 << 63}
+
+	tt := []struct {
+		name       string
+		f128       Fixed128
+		multiplier int64
+		mode       RoundingMode
+		want       int64
+	}{
+		{"1.5 truncate", oneAndHalf, 1, RoundTruncate, 1},
+		{"1.5 floor", oneAndHalf, 1, RoundFloor, 1},
+		{"1.5 ceil", oneAndHalf, 1, RoundCeil, 2},
+		{"1.5 half even", oneAndHalf, 1, RoundHalfEven, 2},
+		{"1.5 half away", oneAndHalf, 1, RoundHalfAwayFromZero, 2},
+		{"2.5 half even", twoAndHalf, 1, RoundHalfEven, 2},
+		{"2.5 half away", twoAndHalf, 1, RoundHalfAwayFromZero, 3},
+		{"-2.5 truncate", twoAndHalf, -1, RoundTruncate, -2},
+		{"-2.5 floor", twoAndHalf, -1, RoundFloor, -3},
+		{"-2.5 ceil", twoAndHalf, -1, RoundCeil, -2},
+		{"-2.5 half even", twoAndHalf, -1, RoundHalfEven, -2},
+		{"-2.5 half away", twoAndHalf, -1, RoundHalfAwayFromZero, -3},
+		{"exact", Fixed128{hi: 3}, 7, RoundCeil, 21},
+		{"min int64", Fixed128{hi: 1 << 63}, -1, RoundHalfEven, -1 << 63},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.f128.MulInt64Rounded(tc.multiplier, tc.mode)
+			if err != nil {
+				t.Fatalf("MulInt64Rounded() returned error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("MulInt64Rounded(%+v, %d, %v) = %d, want %d", tc.f128, tc.multiplier, tc.mode, got, tc.want)
+			}
+		})
+	}
+}
+
 func TestMulInt64Overflow(t *testing.T) {
 	tt := []struct {
 		name       string
@@ -235,13 +481,15 @@ func TestMulInt64Overflow(t *testing.T) {
 	}{
 		{"max hi * large", Fixed128{hi: ^uint64(0)}, 2},
 		{"large * large", Fixed128{hi: 1 << 62}, 1 << 10},
+		{"beyond max int64", Fixed128{hi: 1 << 63}, 1},
 	}
 
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
+			// MulInt64 has always reported ErrorAdditionOverflow
 			_, err := tc.f128.MulInt64(tc.multiplier)
-			if err == nil {
-				t.Errorf("MulInt64(%+v, %d) expected error, got nil", tc.f128, tc.multiplier)
+			if !errors.Is(err, ErrorAdditionOverflow) {
+				t.Errorf("MulInt64(%+v, %d) error = %v, want %v", tc.f128, tc.multiplier, err, ErrorAdditionOverflow)
 			}
 		})
 	}
@@ -264,6 +512,9 @@ func TestAbsCmp(t *testing.T) {
 		{"hi <, lo>", Fixed128{hi: 5, lo: 10}, Fixed128{hi: 10, lo: 5}, -1},
 		{"large values equal", Fixed128{hi: 1<<63 - 1, lo: 1<<63 - 1}, Fixed128{hi: 1<<63 - 1, lo: 1<<63 - 1}, 0},
 		{"large hi diff", Fixed128{hi: 1 << 63}, Fixed128{hi: 1}, 1},
+		{"hi diff beyond 2^63", Fixed128{hi: 1<<63 + 1}, Fixed128{hi: 1}, 1},
+		{"lo diff beyond 2^63", Fixed128{hi: 5, lo: 1}, Fixed128{hi: 5, lo: 1<<63 + 1}, -1},
+		{"max vs zero", Fixed128{hi: ^uint64(0), lo: ^uint64(0)}, Fixed128{}, 1},
 	}
 
 	for _, tc := range tt {
@@ -275,3 +526,234 @@ func TestAbsCmp(t *testing.T) {
 		})
 	}
 }
+
+func TestMul(t *testing.T) {
+	tt := []struct {
+		name string
+		a    Fixed128
+		b    Fixed128
+		want Fixed128
+	}{
+		{"positive * positive", Fixed128{hi: 3}, Fixed128{hi: 4}, Fixed128{hi: 12}},
+		{"positive * negative", Fixed128{hi: 3}, Fixed128{hi: 4, neg: true}, Fixed128{hi: 12, neg: true}},
+		{"negative * negative", Fixed128{hi: 3, neg: true}, Fixed128{hi: 4, neg: true}, Fixed128{hi: 12}},
+		{"half * half", Fixed128{lo: 1 << 63}, Fixed128{lo: 1 << 63}, Fixed128{lo: 1 << 62}},
+		{"one and a half * two", Fixed128{hi: 1, lo: 1 << 63}, Fixed128{hi: 2}, Fixed128{hi: 3}},
+		{"truncated toward zero", Fixed128{lo: 1}, Fixed128{lo: 1 << 63, neg: true}, Fixed128{}},
+		{"zero * negative", Fixed128{}, Fixed128{hi: 7, neg: true}, Fixed128{}},
+		{"max * one", Fixed128{hi: ^uint64(0), lo: ^uint64(0)}, One, Fixed128{hi: ^uint64(0), lo: ^uint64(0)}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.a.Mul(tc.b)
+			if err != nil {
+				t.Fatalf("Mul(%+v, %+v) returned error: %v", tc.a, tc.b, err)
+			}
+			if got != tc.want {
+				t.Errorf("Mul(%+v, %+v) = %+v, want %+v", tc.a, tc.b, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMulOverflow(t *testing.T) {
+	tt := []struct {
+		name string
+		a    Fixed128
+		b    Fixed128
+	}{
+		{"max hi * two", Fixed128{hi: ^uint64(0)}, Fixed128{hi: 2}},
+		{"large * large", Fixed128{hi: 1 << 32}, Fixed128{hi: 1 << 32, neg: true}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.a.Mul(tc.b)
+			if !errors.Is(err, ErrorMultiplicationOverflow) {
+				t.Errorf("Mul(%+v, %+v) error = %v, want %v", tc.a, tc.b, err, ErrorMultiplicationOverflow)
+			}
+		})
+	}
+}
+
+func TestQuo(t *testing.T) {
+	tt := []struct {
+		name string
+		a    Fixed128
+		b    Fixed128
+		want Fixed128
+		err  error
+	}{
+		{"exact", Fixed128{hi: 12}, Fixed128{hi: 4}, Fixed128{hi: 3}, nil},
+		{"fractional", Fixed128{hi: 1}, Fixed128{hi: 4}, Fixed128{lo: 1 << 62}, nil},
+		{"negative divisor", Fixed128{hi: 3}, Fixed128{hi: 2, neg: true}, Fixed128{hi: 1, lo: 1 << 63, neg: true}, nil},
+		{"by a fraction", Fixed128{hi: 3}, Fixed128{lo: 1 << 63}, Fixed128{hi: 6}, nil},
+		{"one third", Fixed128{hi: 1}, Fixed128{hi: 3}, Fixed128{lo: 0x5555555555555555}, nil},
+		{"wide divisor", Fixed128{hi: 1 << 40, lo: 12345}, Fixed128{hi: 1 << 20, lo: 1}, Fixed128{hi: 0xfffff, lo: 0xffffffffffffffff}, nil},
+		{"zero dividend", Fixed128{neg: true}, Fixed128{hi: 3}, Fixed128{}, nil},
+		{"division by zero", Fixed128{hi: 1}, Fixed128{}, Fixed128{}, ErrorDivisionByZero},
+		{"division overflow", Fixed128{hi: 2}, Fixed128{lo: 1}, Fixed128{}, ErrorDivisionOverflow},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.a.Quo(tc.b)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("Quo(%+v, %+v) error = %v, want %v", tc.a, tc.b, err, tc.err)
+			}
+			if got != tc.want {
+				t.Errorf("Quo(%+v, %+v) = %+v, want %+v", tc.a, tc.b, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRem(t *testing.T) {
+	tt := []struct {
+		name string
+		a    Fixed128
+		b    Fixed128
+		want Fixed128
+		err  error
+	}{
+		{"whole", Fixed128{hi: 7}, Fixed128{hi: 3}, Fixed128{hi: 1}, nil},
+		{"fractional", Fixed128{hi: 7, lo: 1 << 63}, Fixed128{hi: 2}, Fixed128{hi: 1, lo: 1 << 63}, nil},
+		{"negative dividend", Fixed128{hi: 7, neg: true}, Fixed128{hi: 3}, Fixed128{hi: 1, neg: true}, nil},
+		{"negative divisor", Fixed128{hi: 7}, Fixed128{hi: 3, neg: true}, Fixed128{hi: 1}, nil},
+		{"exact multiple", Fixed128{hi: 6, neg: true}, Fixed128{hi: 3}, Fixed128{}, nil},
+		{"division by zero", Fixed128{hi: 1}, Fixed128{neg: true}, Fixed128{}, ErrorDivisionByZero},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.a.Rem(tc.b)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("Rem(%+v, %+v) error = %v, want %v", tc.a, tc.b, err, tc.err)
+			}
+			if got != tc.want {
+				t.Errorf("Rem(%+v, %+v) = %+v, want %+v", tc.a, tc.b, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRound(t *testing.T) {
+	// 2.375 and -2.375
+	pos := Fixed128{hi: 2, lo: 3 << 61}
+	neg := Fixed128{hi: 2, lo: 3 << 61, neg: true}
+
+	tt := []struct {
+		name     string
+		f128     Fixed128
+		fracBits uint
+		mode     RoundingMode
+		want     Fixed128
+	}{
+		{"truncate to whole", pos, 0, RoundTruncate, Fixed128{hi: 2}},
+		{"floor to whole", neg, 0, RoundFloor, Fixed128{hi: 3, neg: true}},
+		{"ceil to whole", neg, 0, RoundCeil, Fixed128{hi: 2, neg: true}},
+		{"ceil to whole positive", pos, 0, RoundCeil, Fixed128{hi: 3}},
+		{"half even to quarters", pos, 2, RoundHalfEven, Fixed128{hi: 2, lo: 1 << 63}},
+		{"half away to quarters", neg, 2, RoundHalfAwayFromZero, Fixed128{hi: 2, lo: 1 << 63, neg: true}},
+		{"half even to halves", pos, 1, RoundHalfEven, Fixed128{hi: 2, lo: 1 << 63}},
+		{"exact", pos, 3, RoundCeil, pos},
+		{"all fractional bits", Fixed128{lo: 1}, 64, RoundCeil, Fixed128{lo: 1}},
+		{"carry into whole", Fixed128{hi: 1, lo: ^uint64(0)}, 63, RoundHalfEven, Fixed128{hi: 2}},
+		{"to zero", Fixed128{lo: 1, neg: true}, 0, RoundCeil, Fixed128{}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.f128.Round(tc.fracBits, tc.mode)
+			if err != nil {
+				t.Fatalf("Round(%d, %v) returned error: %v", tc.fracBits, tc.mode, err)
+			}
+			if got != tc.want {
+				t.Errorf("Round(%v, %d, %v) = %v, want %v", tc.f128, tc.fracBits, tc.mode, got, tc.want)
+			}
+		})
+	}
+
+	max := Fixed128{hi: ^uint64(0), lo: 1}
+	if _, err := max.Ceil(); !errors.Is(err, ErrorAdditionOverflow) {
+		t.Errorf("Ceil(%v) error = %v, want %v", max, err, ErrorAdditionOverflow)
+	}
+}
+
+func TestTruncFrac(t *testing.T) {
+	f128 := Fixed128{hi: 7, lo: 1 << 62, neg: true}
+	if got := f128.Trunc(); got != (Fixed128{hi: 7, neg: true}) {
+		t.Errorf("Trunc(%v) = %v", f128, got)
+	}
+	if got := f128.Frac(); got != (Fixed128{lo: 1 << 62, neg: true}) {
+		t.Errorf("Frac(%v) = %v", f128, got)
+	}
+	if got := f128.Abs(); got != (Fixed128{hi: 7, lo: 1 << 62}) {
+		t.Errorf("Abs(%v) = %v", f128, got)
+	}
+	if got := (Fixed128{lo: 5, neg: true}).Trunc(); got != Zero {
+		t.Errorf("Trunc() of a negative fraction = %v, want zero", got)
+	}
+}
+
+func TestShift(t *testing.T) {
+	tt := []struct {
+		name string
+		got  Fixed128
+		want Fixed128
+	}{
+		{"rsh positive", Fixed128{hi: 3}.Rsh(1), Fixed128{hi: 1, lo: 1 << 63}},
+		{"rsh negative exact", Fixed128{hi: 3, neg: true}.Rsh(1), Fixed128{hi: 1, lo: 1 << 63, neg: true}},
+		{"rsh negative inexact", Fixed128{lo: 3, neg: true}.Rsh(1), Fixed128{lo: 2, neg: true}},
+		{"rsh past the end", Fixed128{hi: 3}.Rsh(200), Fixed128{}},
+		{"rsh negative past the end", Fixed128{hi: 3, neg: true}.Rsh(200), Fixed128{lo: 1, neg: true}},
+		{"rsh across words", Fixed128{hi: 1 << 10}.Rsh(70), Fixed128{lo: 1 << 4}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.got != tc.want {
+				t.Errorf("got %v, want %v", tc.got, tc.want)
+			}
+		})
+	}
+
+	got, err := Fixed128{lo: 1 << 62, neg: true}.Lsh(65)
+	if err != nil || got != (Fixed128{hi: 1 << 63, neg: true}) {
+		t.Errorf("Lsh(65) = %v, %v", got, err)
+	}
+	if _, err := (Fixed128{hi: 1 << 63}).Lsh(1); !errors.Is(err, ErrorShiftOverflow) {
+		t.Errorf("Lsh(1) error = %v, want %v", err, ErrorShiftOverflow)
+	}
+	if got, err := Zero.Lsh(500); err != nil || got != Zero {
+		t.Errorf("Zero.Lsh(500) = %v, %v", got, err)
+	}
+}
+
+func TestSqrt(t *testing.T) {
+	tt := []struct {
+		f128 Fixed128
+		want Fixed128
+		err  error
+	}{
+		{Fixed128{hi: 4}, Fixed128{hi: 2}, nil},
+		{Fixed128{lo: 1 << 62}, Fixed128{lo: 1 << 63}, nil},
+		{Fixed128{hi: 2}, Fixed128{hi: 1, lo: 0x6a09e667f3bcc909}, nil},
+		{Fixed128{hi: ^uint64(0), lo: ^uint64(0)}, Fixed128{hi: 1 << 32}, nil},
+		{Fixed128{neg: true}, Fixed128{}, nil},
+		{Fixed128{lo: 1, neg: true}, Fixed128{}, ErrorNegativeSqrt},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.f128.String(), func(t *testing.T) {
+			got, err := tc.f128.Sqrt()
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("Sqrt(%v) error = %v, want %v", tc.f128, err, tc.err)
+			}
+			if got != tc.want {
+				t.Errorf("Sqrt(%v) = %v, want %v", tc.f128, got, tc.want)
+			}
+		})
+	}
+}
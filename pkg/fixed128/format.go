@@ -0,0 +1,252 @@
+package fixed128
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrorInvalidFormat = errors.New("invalid format")
+	ErrorOutOfRange    = errors.New("value out of range")
+)
+
+var _ fmt.Formatter = Fixed128{}
+
+// String returns the hexadecimal form of the Fixed128, 16 digits for the
+// whole part and 16 digits for the fractional part separated by a period,
+// with a leading minus sign if the number is negative.
+func (f128 Fixed128) String() string {
+	return string(f128.appendHex(nil, false))
+}
+
+// Decimal returns the decimal form of the Fixed128 with prec digits after
+// the decimal point, rounded half to even. A negative prec returns the exact
+// decimal expansion, which never needs more than 64 fractional digits.
+func (f128 Fixed128) Decimal(prec int) string {
+	return string(f128.appendDecimal(nil, prec))
+}
+
+// Base64 returns the standard base64 encoding of Bytes.
+// Like Bytes, it drops the sign.
+func (f128 Fixed128) Base64() string {
+	return base64.StdEncoding.EncodeToString(f128.Bytes())
+}
+
+// Base64URL returns the URL-safe base64 encoding of Bytes.
+// Like Bytes, it drops the sign.
+func (f128 Fixed128) Base64URL() string {
+	return base64.URLEncoding.EncodeToString(f128.Bytes())
+}
+
+// Format implements fmt.Formatter.
+// %v, %s and %x print the hexadecimal form and %X its upper-case variant.
+// %d prints the whole part in decimal, truncated toward zero.
+// %f prints the decimal form, exact unless a precision is given.
+// The '+' flag forces a sign, and width pads with spaces, or zeros with '0'.
+func (f128 Fixed128) Format(s fmt.State, verb rune) {
+	var b []byte
+	switch verb {
+	case 'v', 's', 'x':
+		b = f128.appendHex(b, false)
+	case 'X':
+		b = f128.appendHex(b, true)
+	case 'd':
+		if f128.neg && f128.hi != 0 {
+			b = append(b, '-')
+		}
+		b = strconv.AppendUint(b, f128.hi, 10)
+	case 'f':
+		prec, ok := s.Precision()
+		if !ok {
+			prec = -1
+		}
+		b = f128.appendDecimal(b, prec)
+	default:
+		fmt.Fprintf(s, "%%!%c(fixed128.Fixed128=%s)", verb, f128.String())
+		return
+	}
+
+	if s.Flag('+') && (len(b) == 0 || b[0] != '-') {
+		b = append([]byte{'+'}, b...)
+	}
+
+	width, ok := s.Width()
+	if !ok || width <= len(b) {
+		s.Write(b)
+		return
+	}
+
+	padding := strings.Repeat(" ", width-len(b))
+	switch {
+	case s.Flag('-'):
+		s.Write(b)
+		s.Write([]byte(padding))
+	case s.Flag('0'):
+		sign := 0
+		if b[0] == '-' || b[0] == '+' {
+			sign = 1
+		}
+		s.Write(b[:sign])
+		s.Write([]byte(strings.Repeat("0", width-len(b))))
+		s.Write(b[sign:])
+	default:
+		s.Write([]byte(padding))
+		s.Write(b)
+	}
+}
+
+// ParseHex parses the hexadecimal form produced by String. The sign is
+// optional, upper-case digits are accepted, the whole part may have from
+// 1 to 16 digits, and the fractional part, if present, from 0 to 16 digits.
+func ParseHex(s string) (Fixed128, error) {
+	neg, rest := cutSign(s)
+	whole, frac, _ := strings.Cut(rest, ".")
+	if len(whole) == 0 || len(whole) > 16 || len(frac) > 16 {
+		return Zero, fmt.Errorf("%w: %q", ErrorInvalidFormat, s)
+	}
+
+	hi, err := strconv.ParseUint(whole, 16, 64)
+	if err != nil {
+		return Zero, fmt.Errorf("%w: %q", ErrorInvalidFormat, s)
+	}
+
+	var lo uint64
+	if frac != "" {
+		lo, err = strconv.ParseUint(frac, 16, 64)
+		if err != nil {
+			return Zero, fmt.Errorf("%w: %q", ErrorInvalidFormat, s)
+		}
+		lo <<= 4 * (16 - len(frac))
+	}
+
+	return signed(hi, lo, neg), nil
+}
+
+// ParseDecimal parses a decimal number such as "-12.375". Fractions that
+// cannot be represented exactly are rounded half to even. It returns an
+// error if the whole part does not fit in 64 bits.
+func ParseDecimal(s string) (Fixed128, error) {
+	neg, rest := cutSign(s)
+	whole, frac, _ := strings.Cut(rest, ".")
+	if whole == "" || !isDigits(whole) || !isDigits(frac) {
+		return Zero, fmt.Errorf("%w: %q", ErrorInvalidFormat, s)
+	}
+
+	hi, err := strconv.ParseUint(whole, 10, 64)
+	if err != nil {
+		return Zero, fmt.Errorf("%w: %q", ErrorOutOfRange, s)
+	}
+
+	var lo uint64
+	if frac != "" {
+		num, _ := new(big.Int).SetString(frac, 10)
+		num.Lsh(num, 64)
+		den := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(len(frac))), nil)
+
+		q, r := num.QuoRem(num, den, new(big.Int))
+		half := r.Lsh(r, 1).Cmp(den)
+		if half > 0 || (half == 0 && q.Bit(0) == 1) {
+			q.Add(q, big.NewInt(1))
+		}
+
+		// Rounding up may carry into the whole part
+		if q.BitLen() > 64 {
+			hi++
+			if hi == 0 {
+				return Zero, fmt.Errorf("%w: %q", ErrorOutOfRange, s)
+			}
+		}
+		lo = q.Uint64()
+	}
+
+	return signed(hi, lo, neg), nil
+}
+
+// ParseBase64 parses the standard base64 encoding produced by Base64.
+func ParseBase64(s string) (Fixed128, error) {
+	return parseBase64(base64.StdEncoding, s)
+}
+
+// ParseBase64URL parses the URL-safe base64 encoding produced by Base64URL.
+func ParseBase64URL(s string) (Fixed128, error) {
+	return parseBase64(base64.URLEncoding, s)
+}
+
+func parseBase64(enc *base64.Encoding, s string) (Fixed128, error) {
+	b, err := enc.DecodeString(s)
+	if err != nil {
+		return Zero, fmt.Errorf("%w: %q", ErrorInvalidFormat, s)
+	}
+	return FromBytes(b)
+}
+
+func (f128 Fixed128) appendHex(b []byte, upper bool) []byte {
+	format := "%016x.%016x"
+	if upper {
+		format = "%016X.%016X"
+	}
+	if f128.neg {
+		b = append(b, '-')
+	}
+	return fmt.Appendf(b, format, f128.hi, f128.lo)
+}
+
+func (f128 Fixed128) appendDecimal(b []byte, prec int) []byte {
+	digits := strconv.AppendUint(nil, f128.hi, 10)
+	wholeLen := len(digits)
+
+	// Each multiplication by ten moves one decimal digit into the high word
+	frac := f128.lo
+	for n := 0; n < prec || (prec < 0 && frac != 0); n++ {
+		var d uint64
+		d, frac = bits.Mul64(frac, 10)
+		digits = append(digits, byte('0'+d))
+	}
+
+	half := halfCmp(frac, 0)
+	odd := (digits[len(digits)-1]-'0')%2 == 1
+	if frac != 0 && (half > 0 || (half == 0 && odd)) {
+		i := len(digits) - 1
+		for ; i >= 0 && digits[i] == '9'; i-- {
+			digits[i] = '0'
+		}
+		if i < 0 {
+			digits = append([]byte{'1'}, digits...)
+			wholeLen++
+		} else {
+			digits[i]++
+		}
+	}
+
+	if f128.neg {
+		b = append(b, '-')
+	}
+	b = append(b, digits[:wholeLen]...)
+	if len(digits) > wholeLen {
+		b = append(b, '.')
+		b = append(b, digits[wholeLen:]...)
+	}
+	return b
+}
+
+func cutSign(s string) (bool, string) {
+	if rest, ok := strings.CutPrefix(s, "-"); ok {
+		return true, rest
+	}
+	rest, _ := strings.CutPrefix(s, "+")
+	return false, rest
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
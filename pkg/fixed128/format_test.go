@@ -0,0 +1,221 @@
+package fixed128
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestString(t *testing.T) {
+	tt := []struct {
+		f128 Fixed128
+		want string
+	}{
+		{Fixed128{}, "0000000000000000.0000000000000000"},
+		{Fixed128{hi: 1, lo: 1 << 63}, "0000000000000001.8000000000000000"},
+		{Fixed128{hi: 0xabc, lo: 0xdef, neg: true}, "-0000000000000abc.0000000000000def"},
+		{Fixed128{hi: ^uint64(0), lo: ^uint64(0)}, "ffffffffffffffff.ffffffffffffffff"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.want, func(t *testing.T) {
+			if got := tc.f128.String(); got != tc.want {
+				t.Errorf("String() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseHex(t *testing.T) {
+	tt := []struct {
+		s    string
+		want Fixed128
+		err  error
+	}{
+		{"0000000000000001.8000000000000000", Fixed128{hi: 1, lo: 1 << 63}, nil},
+		{"-0000000000000abc.0000000000000def", Fixed128{hi: 0xabc, lo: 0xdef, neg: true}, nil},
+		{"+1.8", Fixed128{hi: 1, lo: 1 << 63}, nil},
+		{"ABC.DEF", Fixed128{hi: 0xabc, lo: 0xdef << 52}, nil},
+		{"7", Fixed128{hi: 7}, nil},
+		{"7.", Fixed128{hi: 7}, nil},
+		{"-0.0", Fixed128{}, nil},
+		{"", Fixed128{}, ErrorInvalidFormat},
+		{".8", Fixed128{}, ErrorInvalidFormat},
+		{"1.g", Fixed128{}, ErrorInvalidFormat},
+		{"0x1.0", Fixed128{}, ErrorInvalidFormat},
+		{"--1.0", Fixed128{}, ErrorInvalidFormat},
+		{"10000000000000000.0", Fixed128{}, ErrorInvalidFormat},
+		{"1.00000000000000000", Fixed128{}, ErrorInvalidFormat},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.s, func(t *testing.T) {
+			got, err := ParseHex(tc.s)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("ParseHex(%q) error = %v, want %v", tc.s, err, tc.err)
+			}
+			if got != tc.want {
+				t.Errorf("ParseHex(%q) = %v, want %v", tc.s, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDecimal(t *testing.T) {
+	tt := []struct {
+		f128 Fixed128
+		prec int
+		want string
+	}{
+		{Fixed128{}, -1, "0"},
+		{Fixed128{hi: 1, lo: 1 << 63}, -1, "1.5"},
+		{Fixed128{hi: 12, lo: 3 << 61, neg: true}, -1, "-12.375"},
+		{Fixed128{lo: 1}, -1, "0.0000000000000000000542101086242752217003726400434970855712890625"},
+		{Fixed128{lo: 0x5555555555555555}, 5, "0.33333"},
+		{Fixed128{hi: 1, lo: 1 << 63}, 3, "1.500"},
+		{Fixed128{hi: 9, lo: ^uint64(0)}, 2, "10.00"},
+		{Fixed128{hi: 2, lo: 1 << 63}, 0, "2"},
+		{Fixed128{hi: 3, lo: 1 << 63}, 0, "4"},
+		{Fixed128{hi: ^uint64(0), lo: ^uint64(0)}, 0, "18446744073709551616"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.want, func(t *testing.T) {
+			if got := tc.f128.Decimal(tc.prec); got != tc.want {
+				t.Errorf("Decimal(%d) = %q, want %q", tc.prec, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseDecimal(t *testing.T) {
+	tt := []struct {
+		s    string
+		want Fixed128
+		err  error
+	}{
+		{"1.5", Fixed128{hi: 1, lo: 1 << 63}, nil},
+		{"-12.375", Fixed128{hi: 12, lo: 3 << 61, neg: true}, nil},
+		{"0.1", Fixed128{lo: 0x199999999999999a}, nil},
+		{"42", Fixed128{hi: 42}, nil},
+		{"0.99999999999999999999999", Fixed128{hi: 1}, nil},
+		{"18446744073709551615.5", Fixed128{hi: ^uint64(0), lo: 1 << 63}, nil},
+		{"18446744073709551616", Fixed128{}, ErrorOutOfRange},
+		{"18446744073709551615.99999999999999999999999", Fixed128{}, ErrorOutOfRange},
+		{"1.2.3", Fixed128{}, ErrorInvalidFormat},
+		{"1e3", Fixed128{}, ErrorInvalidFormat},
+		{"", Fixed128{}, ErrorInvalidFormat},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.s, func(t *testing.T) {
+			got, err := ParseDecimal(tc.s)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("ParseDecimal(%q) error = %v, want %v", tc.s, err, tc.err)
+			}
+			if got != tc.want {
+				t.Errorf("ParseDecimal(%q) = %v, want %v", tc.s, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	f128 := Fixed128{hi: 12, lo: 3 << 61, neg: true}
+	pos := Fixed128{hi: 0xa, lo: 1 << 63}
+
+	tt := []struct {
+		format string
+		value  Fixed128
+		want   string
+	}{
+		{"%v", f128, "-000000000000000c.6000000000000000"},
+		{"%s", pos, "000000000000000a.8000000000000000"},
+		{"%x", pos, "000000000000000a.8000000000000000"},
+		{"%X", pos, "000000000000000A.8000000000000000"},
+		{"%d", f128, "-12"},
+		{"%f", f128, "-12.375"},
+		{"%.1f", f128, "-12.4"},
+		{"%+f", pos, "+10.5"},
+		{"%8.2f", pos, "   10.50"},
+		{"%-8.2f|", pos, "10.50   |"},
+		{"%08.2f", f128, "-0012.38"},
+		{"%q", pos, "%!q(fixed128.Fixed128=000000000000000a.8000000000000000)"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.format, func(t *testing.T) {
+			if got := fmt.Sprintf(tc.format, tc.value); got != tc.want {
+				t.Errorf("Sprintf(%q) = %q, want %q", tc.format, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBase64(t *testing.T) {
+	f128 := Fixed128{hi: 0xfbff, lo: 0xfeff}
+
+	std := f128.Base64()
+	if std != "AAAAAAAA+/8AAAAAAAD+/w==" {
+		t.Errorf("Base64() = %q", std)
+	}
+	url := f128.Base64URL()
+	if url != "AAAAAAAA-_8AAAAAAAD-_w==" {
+		t.Errorf("Base64URL() = %q", url)
+	}
+
+	if got, err := ParseBase64(std); err != nil || got != f128 {
+		t.Errorf("ParseBase64(%q) = %v, %v, want %v", std, got, err, f128)
+	}
+	if got, err := ParseBase64URL(url); err != nil || got != f128 {
+		t.Errorf("ParseBase64URL(%q) = %v, %v, want %v", url, got, err, f128)
+	}
+	if _, err := ParseBase64(url); !errors.Is(err, ErrorInvalidFormat) {
+		t.Errorf("ParseBase64(%q) error = %v, want %v", url, err, ErrorInvalidFormat)
+	}
+}
+
+func FuzzHexRoundTrip(f *testing.F) {
+	f.Add(uint64(0), uint64(0), false)
+	f.Add(uint64(1), uint64(1<<63), true)
+	f.Add(^uint64(0), ^uint64(0), false)
+
+	f.Fuzz(func(t *testing.T, hi, lo uint64, neg bool) {
+		f128 := signed(hi, lo, neg)
+		got, err := ParseHex(f128.String())
+		if err != nil {
+			t.Fatalf("ParseHex(%q) returned error: %v", f128.String(), err)
+		}
+		if got != f128 {
+			t.Fatalf("ParseHex(%q) = %v, want %v", f128.String(), got, f128)
+		}
+	})
+}
+
+func FuzzDecimalRoundTrip(f *testing.F) {
+	f.Add(uint64(0), uint64(0), false)
+	f.Add(uint64(1), uint64(1), true)
+	f.Add(^uint64(0), ^uint64(0), false)
+
+	f.Fuzz(func(t *testing.T, hi, lo uint64, neg bool) {
+		f128 := signed(hi, lo, neg)
+		s := f128.Decimal(-1)
+		got, err := ParseDecimal(s)
+		if err != nil {
+			t.Fatalf("ParseDecimal(%q) returned error: %v", s, err)
+		}
+		if got != f128 {
+			t.Fatalf("ParseDecimal(%q) = %v, want %v", s, got, f128)
+		}
+
+		// Twenty digits are always enough to identify a 64-bit fraction
+		s = f128.Decimal(20)
+		got, err = ParseDecimal(s)
+		if err != nil {
+			t.Fatalf("ParseDecimal(%q) returned error: %v", s, err)
+		}
+		if got != f128 {
+			t.Fatalf("ParseDecimal(%q) = %v, want %v", s, got, f128)
+		}
+	})
+}
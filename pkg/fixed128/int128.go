@@ -0,0 +1,124 @@
+package fixed128
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// Int128 is a 64.64 fixed-point number like Fixed128, but stored as a
+// 128-bit two's-complement integer rather than as a sign and magnitude.
+// It has a single zero, its Bytes sort in numeric order, and Add and Sub
+// need no sign handling. In exchange its range is [-2^63, 2^63) rather
+// than (-2^64, 2^64).
+type Int128 struct {
+	hi uint64
+	lo uint64
+}
+
+const signBit = 1 << 63
+
+// ToInt128 converts a Fixed128 to an Int128.
+// It returns an error if the value is outside the range of Int128.
+func ToInt128(f128 Fixed128) (Int128, error) {
+	if f128.hi >= signBit && !(f128.neg && f128.hi == signBit && f128.lo == 0) {
+		return Int128{}, ErrorOutOfRange
+	}
+
+	i := Int128{hi: f128.hi, lo: f128.lo}
+	if f128.neg {
+		i = i.Neg()
+	}
+	return i, nil
+}
+
+// Fixed128 converts the Int128 to a Fixed128, which is always exact.
+func (i Int128) Fixed128() Fixed128 {
+	if i.hi&signBit == 0 {
+		return Fixed128{hi: i.hi, lo: i.lo}
+	}
+	abs := i.Neg()
+	return Fixed128{hi: abs.hi, lo: abs.lo, neg: true}
+}
+
+func (i Int128) String() string {
+	return i.Fixed128().String()
+}
+
+func (i Int128) IsZero() bool {
+	return i == Int128{}
+}
+
+// Sign returns -1, 0 or 1 depending on the sign of the Int128.
+func (i Int128) Sign() int {
+	nonZero := (i.hi | i.lo | -(i.hi | i.lo)) >> 63
+	return int(int64(i.hi)>>63) | int(nonZero)
+}
+
+// Neg returns the Int128 negated. The minimum value has no positive
+// counterpart and is returned unchanged.
+func (i Int128) Neg() Int128 {
+	lo, borrow := bits.Sub64(0, i.lo, 0)
+	hi, _ := bits.Sub64(0, i.hi, borrow)
+	return Int128{hi: hi, lo: lo}
+}
+
+// Add adds two Int128 numbers and returns the result.
+// It returns an error if the addition results in an overflow.
+func (i Int128) Add(other Int128) (Int128, error) {
+	lo, carry := bits.Add64(i.lo, other.lo, 0)
+	hi, _ := bits.Add64(i.hi, other.hi, carry)
+
+	// Overflow when both operands share a sign the result does not
+	if (i.hi^hi)&(other.hi^hi)&signBit != 0 {
+		return Int128{}, ErrorAdditionOverflow
+	}
+	return Int128{hi: hi, lo: lo}, nil
+}
+
+// Sub subtracts another Int128 number from the current one and returns the result.
+// It returns an error if the subtraction results in an overflow.
+func (i Int128) Sub(other Int128) (Int128, error) {
+	lo, borrow := bits.Sub64(i.lo, other.lo, 0)
+	hi, _ := bits.Sub64(i.hi, other.hi, borrow)
+
+	// Overflow when the operands differ in sign and the result
+	// does not share the sign of the minuend
+	if (i.hi^other.hi)&(i.hi^hi)&signBit != 0 {
+		return Int128{}, ErrorSubtractionUnderflow
+	}
+	return Int128{hi: hi, lo: lo}, nil
+}
+
+// Cmp compares two Int128 numbers, returning -1, 0 or 1.
+func (i Int128) Cmp(other Int128) int {
+	// Flipping the sign bits turns signed order into unsigned order
+	ahi, bhi := i.hi^signBit, other.hi^signBit
+
+	_, borrow := bits.Sub64(i.lo, other.lo, 0)
+	_, less := bits.Sub64(ahi, bhi, borrow)
+	_, borrow = bits.Sub64(other.lo, i.lo, 0)
+	_, greater := bits.Sub64(bhi, ahi, borrow)
+
+	return int(greater) - int(less)
+}
+
+// Bytes returns a 16-byte big-endian form of the Int128 with the sign bit
+// inverted, so that comparing the bytes of two values orders them the same
+// way as Cmp.
+func (i Int128) Bytes() []byte {
+	b := make([]byte, 16)
+	binary.BigEndian.PutUint64(b[:8], i.hi^signBit)
+	binary.BigEndian.PutUint64(b[8:], i.lo)
+	return b
+}
+
+// Int128FromBytes decodes the form returned by Int128.Bytes.
+func Int128FromBytes(b []byte) (Int128, error) {
+	if len(b) != 16 {
+		return Int128{}, ErrorBadByteLength
+	}
+	return Int128{
+		hi: binary.BigEndian.Uint64(b[:8]) ^ signBit,
+		lo: binary.BigEndian.Uint64(b[8:]),
+	}, nil
+}
@@ -0,0 +1,139 @@
+package fixed128
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/seannyphoenix/binarytime/pkg/fixed128legacy"
+)
+
+func TestToInt128(t *testing.T) {
+	tt := []struct {
+		name string
+		f128 Fixed128
+		want Int128
+		err  error
+	}{
+		{"zero", Fixed128{}, Int128{}, nil},
+		{"negative zero", Fixed128{neg: true}, Int128{}, nil},
+		{"one", One, Int128{hi: 1}, nil},
+		{"negative one", NegativeOne, Int128{hi: ^uint64(0)}, nil},
+		{"negative half", Fixed128{lo: 1 << 63, neg: true}, Int128{hi: ^uint64(0), lo: 1 << 63}, nil},
+		{"minimum", Fixed128{hi: 1 << 63, neg: true}, Int128{hi: 1 << 63}, nil},
+		{"below minimum", Fixed128{hi: 1 << 63, lo: 1, neg: true}, Int128{}, ErrorOutOfRange},
+		{"above maximum", Fixed128{hi: 1 << 63}, Int128{}, ErrorOutOfRange},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ToInt128(tc.f128)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("ToInt128(%+v) error = %v, want %v", tc.f128, err, tc.err)
+			}
+			if got != tc.want {
+				t.Errorf("ToInt128(%+v) = %+v, want %+v", tc.f128, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestInt128_Add(t *testing.T) {
+	tt := []struct {
+		name string
+		a    Int128
+		b    Int128
+		want Int128
+		err  error
+	}{
+		{"positive + positive", Int128{hi: 5, lo: 10}, Int128{hi: 3, lo: 20}, Int128{hi: 8, lo: 30}, nil},
+		{"positive + negative", Int128{hi: 5}, Int128{hi: ^uint64(2)}, Int128{hi: 2}, nil},
+		{"cancel to zero", Int128{hi: 5, lo: 1}, Int128{hi: 5, lo: 1}.Neg(), Int128{}, nil},
+		{"overflow", Int128{hi: 1<<63 - 1}, Int128{hi: 1}, Int128{}, ErrorAdditionOverflow},
+		{"negative overflow", Int128{hi: 1 << 63}, Int128{hi: ^uint64(0)}, Int128{}, ErrorAdditionOverflow},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.a.Add(tc.b)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("Add(%v, %v) error = %v, want %v", tc.a, tc.b, err, tc.err)
+			}
+			if got != tc.want {
+				t.Errorf("Add(%v, %v) = %v, want %v", tc.a, tc.b, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestInt128_Sign(t *testing.T) {
+	tt := []struct {
+		i    Int128
+		want int
+	}{
+		{Int128{}, 0},
+		{Int128{lo: 1}, 1},
+		{Int128{hi: 1<<63 - 1}, 1},
+		{Int128{hi: ^uint64(0), lo: ^uint64(0)}, -1},
+		{Int128{hi: 1 << 63}, -1},
+	}
+
+	for _, tc := range tt {
+		if got := tc.i.Sign(); got != tc.want {
+			t.Errorf("Sign(%v) = %d, want %d", tc.i, got, tc.want)
+		}
+	}
+}
+
+func FuzzInt128(f *testing.F) {
+	f.Add(uint64(0), uint64(0), uint64(0), uint64(1))
+	f.Add(uint64(1<<63), uint64(0), ^uint64(0), ^uint64(0))
+	f.Add(uint64(1<<63-1), ^uint64(0), uint64(0), uint64(1))
+	f.Add(uint64(5), uint64(1<<63), uint64(1<<63+5), uint64(1<<63))
+
+	f.Fuzz(func(t *testing.T, ahi, alo, bhi, blo uint64) {
+		a, b := Int128{hi: ahi, lo: alo}, Int128{hi: bhi, lo: blo}
+		la, lb := toLegacy(a.Fixed128()), toLegacy(b.Fixed128())
+
+		if got, want := a.Cmp(b), la.Cmp(lb); got != want {
+			t.Fatalf("Cmp(%v, %v) = %d, want %d", a, b, got, want)
+		}
+		if got, want := bytes.Compare(a.Bytes(), b.Bytes()), la.Cmp(lb); got != want {
+			t.Fatalf("bytes.Compare(%x, %x) = %d, want %d", a.Bytes(), b.Bytes(), got, want)
+		}
+
+		back, err := ToInt128(a.Fixed128())
+		if err != nil || back != a {
+			t.Fatalf("ToInt128(%v.Fixed128()) = %v, %v", a, back, err)
+		}
+		if fromBytes, _ := Int128FromBytes(a.Bytes()); fromBytes != a {
+			t.Fatalf("Int128FromBytes(%x) = %v, want %v", a.Bytes(), fromBytes, a)
+		}
+
+		// Int128 operands are below 2^127 in magnitude, so the legacy
+		// sum and difference always fit
+		sum, err := a.Add(b)
+		want, _ := la.Add(lb)
+		checkInt128(t, "Add", sum, err, want)
+
+		diff, err := a.Sub(b)
+		want, _ = la.Sub(lb)
+		checkInt128(t, "Sub", diff, err, want)
+	})
+}
+
+// checkInt128 compares a result against the legacy big.Int result,
+// expecting an error exactly when it falls outside [-2^127, 2^127).
+func checkInt128(t *testing.T, op string, got Int128, err error, want fixed128legacy.Fixed128) {
+	t.Helper()
+
+	hi, lo, neg := want.Parts()
+	expected, rangeErr := ToInt128(Fixed128{hi: hi, lo: lo, neg: neg})
+
+	if (err != nil) != (rangeErr != nil) {
+		t.Fatalf("%s error = %v, want error %t", op, err, rangeErr != nil)
+	}
+	if err == nil && got != expected {
+		t.Fatalf("%s = %v, want %v", op, got, expected)
+	}
+}
@@ -2,17 +2,23 @@ package fixed128
 
 import (
 	"errors"
+	"math"
 	"math/bits"
 )
 
 var (
-	ErrorDivisionByZero       = errors.New("division by zero")
-	ErrorAdditionOverflow     = errors.New("addition overflow")
-	ErrorSubtractionUnderflow = errors.New("subtraction underflow")
-	ErrorBadByteLength        = errors.New("bad byte length")
+	ErrorDivisionByZero         = errors.New("division by zero")
+	ErrorAdditionOverflow       = errors.New("addition overflow")
+	ErrorSubtractionUnderflow   = errors.New("subtraction underflow")
+	ErrorMultiplicationOverflow = errors.New("multiplication overflow")
+	ErrorDivisionOverflow       = errors.New("division overflow")
+	ErrorBadByteLength          = errors.New("bad byte length")
+	ErrorUnknownVersion         = errors.New("unknown encoding version")
+	ErrorShiftOverflow          = errors.New("shift overflow")
+	ErrorNegativeSqrt           = errors.New("square root of negative number")
 )
 
-func divide(x, y int64) (Fixed128, error) {
+func divide(x, y int64, mode RoundingMode) (Fixed128, error) {
 	if y == 0 {
 		return Zero, ErrorDivisionByZero
 	}
@@ -21,9 +27,14 @@ func divide(x, y int64) (Fixed128, error) {
 	negY, absY := normalize(y)
 	neg := negX != negY
 
-	hi, lo := getParts(absX, absY)
+	hi, lo, rem := getParts(absX, absY)
+	if mode.roundUp(neg, lo&1 == 1, rem != 0, halfCmp(rem, absY)) {
+		var carry uint64
+		lo, carry = bits.Add64(lo, 1, 0)
+		hi += carry
+	}
 
-	return Fixed128{hi: hi, lo: lo, neg: neg}, nil
+	return signed(hi, lo, neg), nil
 }
 
 func normalize(v int64) (bool, uint64) {
@@ -33,27 +44,12 @@ func normalize(v int64) (bool, uint64) {
 	return neg, abs
 }
 
-func getParts(x, y uint64) (uint64, uint64) {
-	var hi, lo uint64
-
-	hi = x / y
-	part := x % y
-
-	shift := bits.LeadingZeros64(y)
-	y <<= shift
-	part <<= shift
-
-	var i int
-	for ; i < 64 && y > 0 && part > 0; i++ {
-		y >>= 1
-		bit := 1 & ^(uint64(int64(part-y) >> 63))
-		part -= bit * y
-		lo <<= 1
-		lo |= bit
-	}
-
-	lo <<= (64 - i)
-	return hi, lo
+// getParts divides x by y, returning the whole part, the first 64
+// fractional bits and the remainder left after those bits.
+func getParts(x, y uint64) (uint64, uint64, uint64) {
+	hi := x / y
+	lo, rem := bits.Div64(x%y, 0, y)
+	return hi, lo, rem
 }
 
 func add(a, b Fixed128) (Fixed128, error) {
@@ -84,29 +80,44 @@ func sub(a, b Fixed128) (Fixed128, error) {
 	}, nil
 }
 
-func absCmp(a, b Fixed128) int {
-	hiDiff := int64(a.hi - b.hi)
-	loDiff := int64(a.lo - b.lo)
+// addWrapping adds a and b, dropping any carry out of the magnitude.
+func addWrapping(a, b Fixed128) Fixed128 {
+	if a.neg != b.neg {
+		// Values of opposite signs never overflow
+		result, _ := a.Add(b)
+		return result
+	}
 
-	hiSign := hiDiff>>63 - ((-hiDiff) >> 63)
-	loSign := loDiff>>63 - ((-loDiff) >> 63)
+	lo, carry := bits.Add64(a.lo, b.lo, 0)
+	hi, _ := bits.Add64(a.hi, b.hi, carry)
+	return signed(hi, lo, a.neg)
+}
+
+// saturate returns the bound an overflowing result of the given sign clamps to.
+func saturate(neg bool) Fixed128 {
+	if neg {
+		return Min
+	}
+	return Max
+}
 
-	mask := (hiDiff | -hiDiff) >> 63
+func absCmp(a, b Fixed128) int {
+	// The final borrow of a 128-bit subtraction tells which side is smaller
+	_, borrow := bits.Sub64(a.lo, b.lo, 0)
+	_, less := bits.Sub64(a.hi, b.hi, borrow)
+	_, borrow = bits.Sub64(b.lo, a.lo, 0)
+	_, greater := bits.Sub64(b.hi, a.hi, borrow)
 
-	return int((hiSign & mask) | (loSign & ^mask))
+	return int(greater) - int(less)
 }
 
-func mulInt64(f128 Fixed128, multiplier int64) (int64, error) {
-	// Handle sign
-	negMul := multiplier < 0
-	absMul := uint64(multiplier)
-	if negMul {
-		absMul = uint64(-multiplier)
-	}
+func mulInt64(f128 Fixed128, multiplier int64, mode RoundingMode) (int64, error) {
+	negMul, absMul := normalize(multiplier)
+	neg := f128.neg != negMul
 
 	// Multiply hi and lo parts using 64-bit multiplication
 	hiHigh, hiLow := bits.Mul64(f128.hi, absMul)
-	loHigh, _ := bits.Mul64(f128.lo, absMul)
+	loHigh, frac := bits.Mul64(f128.lo, absMul)
 
 	// Add fractional overflow from lo to hi result
 	result, carry := bits.Add64(hiLow, loHigh, 0)
@@ -114,11 +125,365 @@ func mulInt64(f128 Fixed128, multiplier int64) (int64, error) {
 		return 0, ErrorAdditionOverflow
 	}
 
+	if mode.roundUp(neg, result&1 == 1, frac != 0, halfCmp(frac, 0)) {
+		result++
+		if result == 0 {
+			return 0, ErrorAdditionOverflow
+		}
+	}
+
+	// A negative result may reach one further than a positive one
+	limit := uint64(math.MaxInt64)
+	if neg {
+		limit++
+	}
+	if result > limit {
+		return 0, ErrorAdditionOverflow
+	}
+
 	// Apply sign: flip if f128 and multiplier have different signs
-	signResult := int64(result)
-	if f128.neg != negMul {
-		signResult = -signResult
+	if neg {
+		return int64(-result), nil
+	}
+	return int64(result), nil
+}
+
+func mul(a, b Fixed128) (Fixed128, error) {
+	p := mulWords(a.hi, a.lo, b.hi, b.lo)
+	if p[3] != 0 {
+		return Zero, ErrorMultiplicationOverflow
+	}
+
+	return signed(p[2], p[1], a.neg != b.neg), nil
+}
+
+func quo(a, b Fixed128) (Fixed128, error) {
+	if b.hi == 0 && b.lo == 0 {
+		return Zero, ErrorDivisionByZero
+	}
+
+	// The quotient only fits in 128 bits if a < b * 2^64
+	if b.hi == 0 && a.hi >= b.lo {
+		return Zero, ErrorDivisionOverflow
+	}
+
+	q, _ := divWords([]uint64{0, a.lo, a.hi}, []uint64{b.lo, b.hi})
+	return signed(q[1], q[0], a.neg != b.neg), nil
+}
+
+func rem(a, b Fixed128) (Fixed128, error) {
+	if b.hi == 0 && b.lo == 0 {
+		return Zero, ErrorDivisionByZero
+	}
+
+	_, r := divWords([]uint64{a.lo, a.hi}, []uint64{b.lo, b.hi})
+	return signed(r[1], r[0], a.neg), nil
+}
+
+// signed builds a Fixed128 from a magnitude and a sign,
+// never producing a negative zero.
+func signed(hi, lo uint64, neg bool) Fixed128 {
+	return Fixed128{
+		hi:  hi,
+		lo:  lo,
+		neg: neg && hi|lo != 0,
+	}
+}
+
+// mulWords returns the full 256-bit product of two 128-bit magnitudes
+// as little-endian 64-bit words.
+func mulWords(ahi, alo, bhi, blo uint64) [4]uint64 {
+	var p [4]uint64
+	var c uint64
+
+	p[1], p[0] = bits.Mul64(alo, blo)
+
+	h, l := bits.Mul64(ahi, blo)
+	p[1], c = bits.Add64(p[1], l, 0)
+	p[2] = h + c
+
+	h, l = bits.Mul64(alo, bhi)
+	p[1], c = bits.Add64(p[1], l, 0)
+	p[2], c = bits.Add64(p[2], h, c)
+	p[3] = c
+
+	h, l = bits.Mul64(ahi, bhi)
+	p[2], c = bits.Add64(p[2], l, 0)
+	p[3] += h + c
+
+	return p
+}
+
+// divWords divides u by v, both little-endian slices of 64-bit words,
+// using Knuth's algorithm D. It returns the quotient in len(u) words and
+// the remainder in len(v) words. v must not be zero.
+func divWords(u, v []uint64) ([]uint64, []uint64) {
+	q := make([]uint64, len(u))
+	r := make([]uint64, len(v))
+
+	n := len(v)
+	for n > 0 && v[n-1] == 0 {
+		n--
+	}
+	if n == 0 {
+		panic(ErrorDivisionByZero)
+	}
+
+	if n == 1 {
+		var rem uint64
+		for i := len(u) - 1; i >= 0; i-- {
+			q[i], rem = bits.Div64(rem, u[i], v[0])
+		}
+		r[0] = rem
+		return q, r
+	}
+
+	m := len(u) - n
+	if m < 0 {
+		copy(r, u)
+		return q, r
+	}
+
+	// Normalize so the top word of the divisor has its high bit set
+	s := uint(bits.LeadingZeros64(v[n-1]))
+	vn := make([]uint64, n)
+	for i := n - 1; i > 0; i-- {
+		vn[i] = v[i]<<s | v[i-1]>>(64-s)
+	}
+	vn[0] = v[0] << s
+
+	un := make([]uint64, len(u)+1)
+	un[len(u)] = u[len(u)-1] >> (64 - s)
+	for i := len(u) - 1; i > 0; i-- {
+		un[i] = u[i]<<s | u[i-1]>>(64-s)
+	}
+	un[0] = u[0] << s
+
+	top := vn[n-1]
+	for j := m; j >= 0; j-- {
+		// Estimate the quotient digit from the top two words
+		var qhat, rhat, c uint64
+		if un[j+n] >= top {
+			qhat = ^uint64(0)
+			rhat, c = bits.Add64(un[j+n-1], top, 0)
+		} else {
+			qhat, rhat = bits.Div64(un[j+n], un[j+n-1], top)
+		}
+
+		// Refine the estimate using the next word of the divisor
+		for c == 0 {
+			ph, pl := bits.Mul64(qhat, vn[n-2])
+			if ph < rhat || (ph == rhat && pl <= un[j+n-2]) {
+				break
+			}
+			qhat--
+			rhat, c = bits.Add64(rhat, top, 0)
+		}
+
+		// Multiply and subtract
+		var borrow, carry uint64
+		for i := range n {
+			ph, pl := bits.Mul64(qhat, vn[i])
+			pl, c = bits.Add64(pl, carry, 0)
+			carry = ph + c
+			un[i+j], borrow = bits.Sub64(un[i+j], pl, borrow)
+		}
+		un[j+n], borrow = bits.Sub64(un[j+n], carry, borrow)
+
+		// The estimate was one too large; add the divisor back
+		if borrow != 0 {
+			qhat--
+			c = 0
+			for i := range n {
+				un[i+j], c = bits.Add64(un[i+j], vn[i], c)
+			}
+			un[j+n] += c
+		}
+
+		q[j] = qhat
+	}
+
+	for i := range n {
+		r[i] = un[i]>>s | un[i+1]<<(64-s)
+	}
+
+	return q, r
+}
+
+// round rounds the magnitude of f128 to fracBits fractional bits.
+func round(f128 Fixed128, fracBits uint, mode RoundingMode) (Fixed128, error) {
+	if fracBits >= 64 {
+		return f128, nil
+	}
+
+	// A unit of zero stands for 2^64, one whole
+	shift := 64 - fracBits
+	unit := uint64(1) << shift
+	discarded := f128.lo & (unit - 1)
+
+	hi, lo := f128.hi, f128.lo&^(unit-1)
+	odd := (lo>>shift)&1 == 1
+	if unit == 0 {
+		odd = hi&1 == 1
+	}
+
+	if mode.roundUp(f128.neg, odd, discarded != 0, halfCmp(discarded, unit)) {
+		var carry uint64
+		lo, carry = bits.Add64(lo, unit, 0)
+		if unit == 0 {
+			carry = 1
+		}
+		hi, carry = bits.Add64(hi, 0, carry)
+		if carry != 0 {
+			return Zero, ErrorAdditionOverflow
+		}
+	}
+
+	return signed(hi, lo, f128.neg), nil
+}
+
+func lsh(f128 Fixed128, n uint) (Fixed128, error) {
+	length := uint(bitLenWords([]uint64{f128.lo, f128.hi}))
+	if length == 0 {
+		return Zero, nil
+	}
+	if n > 128-length {
+		return Zero, ErrorShiftOverflow
+	}
+
+	if n >= 64 {
+		return Fixed128{hi: f128.lo << (n - 64), neg: f128.neg}, nil
+	}
+	return Fixed128{
+		hi:  f128.hi<<n | f128.lo>>(64-n),
+		lo:  f128.lo << n,
+		neg: f128.neg,
+	}, nil
+}
+
+func rsh(f128 Fixed128, n uint) Fixed128 {
+	var hi, lo, dropped uint64
+	switch {
+	case n == 0:
+		hi, lo = f128.hi, f128.lo
+	case n < 64:
+		hi = f128.hi >> n
+		lo = f128.lo>>n | f128.hi<<(64-n)
+		dropped = f128.lo << (64 - n)
+	case n < 128:
+		lo = f128.hi >> (n - 64)
+		dropped = f128.lo | f128.hi<<(128-n)
+	default:
+		dropped = f128.hi | f128.lo
+	}
+
+	// Shifting a negative number rounds toward negative infinity,
+	// which increases the magnitude when any set bits are dropped
+	if f128.neg && dropped != 0 {
+		var carry uint64
+		lo, carry = bits.Add64(lo, 1, 0)
+		hi += carry
+	}
+
+	return signed(hi, lo, f128.neg)
+}
+
+func sqrt(f128 Fixed128) (Fixed128, error) {
+	if f128.hi == 0 && f128.lo == 0 {
+		return Zero, nil
+	}
+	if f128.neg {
+		return Zero, ErrorNegativeSqrt
+	}
+
+	// sqrt(m / 2^64) = sqrt(m * 2^64) / 2^64, so take the integer
+	// square root of the magnitude shifted up by one word
+	root, rem := sqrtWords([3]uint64{0, f128.lo, f128.hi})
+
+	// Round to nearest: (r + 1/2)^2 = r^2 + r + 1/4, and since the
+	// remainder is an integer it can never fall exactly on the tie
+	if cmpWords(rem[:], root[:]) > 0 {
+		var carry uint64
+		root[0], carry = bits.Add64(root[0], 1, 0)
+		root[1] += carry
+	}
+
+	return Fixed128{hi: root[1], lo: root[0]}, nil
+}
+
+// sqrtWords returns the integer square root of n and the remainder
+// n - root^2, using the binary digit-by-digit method.
+func sqrtWords(n [3]uint64) ([3]uint64, [3]uint64) {
+	var root, bit, sum [3]uint64
+
+	length := bitLenWords(n[:])
+	if length == 0 {
+		return root, n
+	}
+	top := uint(length-1) &^ 1
+	bit[top/64] = 1 << (top % 64)
+
+	for bitLenWords(bit[:]) != 0 {
+		addWords(sum[:], root[:], bit[:])
+		if cmpWords(n[:], sum[:]) >= 0 {
+			subWords(n[:], n[:], sum[:])
+			rshWords(root[:], 1)
+			addWords(root[:], root[:], bit[:])
+		} else {
+			rshWords(root[:], 1)
+		}
+		rshWords(bit[:], 2)
 	}
 
-	return signResult, nil
+	return root, n
+}
+
+// addWords sets z = x + y over little-endian words of equal length,
+// discarding any final carry.
+func addWords(z, x, y []uint64) {
+	var carry uint64
+	for i := range z {
+		z[i], carry = bits.Add64(x[i], y[i], carry)
+	}
+}
+
+// subWords sets z = x - y over little-endian words of equal length,
+// discarding any final borrow.
+func subWords(z, x, y []uint64) {
+	var borrow uint64
+	for i := range z {
+		z[i], borrow = bits.Sub64(x[i], y[i], borrow)
+	}
+}
+
+// cmpWords compares little-endian words of equal length.
+func cmpWords(x, y []uint64) int {
+	for i := len(x) - 1; i >= 0; i-- {
+		switch {
+		case x[i] < y[i]:
+			return -1
+		case x[i] > y[i]:
+			return 1
+		}
+	}
+	return 0
+}
+
+// rshWords shifts little-endian words right by n < 64 bits in place.
+func rshWords(x []uint64, n uint) {
+	for i := range x {
+		x[i] >>= n
+		if i+1 < len(x) {
+			x[i] |= x[i+1] << (64 - n)
+		}
+	}
+}
+
+func bitLenWords(x []uint64) int {
+	for i := len(x) - 1; i >= 0; i-- {
+		if x[i] != 0 {
+			return i*64 + bits.Len64(x[i])
+		}
+	}
+	return 0
 }
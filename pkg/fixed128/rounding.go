@@ -0,0 +1,73 @@
+package fixed128
+
+// RoundingMode determines how a result that cannot be represented
+// exactly is rounded to a representable value.
+type RoundingMode int
+
+const (
+	// RoundTruncate rounds toward zero.
+	RoundTruncate RoundingMode = iota
+	// RoundFloor rounds toward negative infinity.
+	RoundFloor
+	// RoundCeil rounds toward positive infinity.
+	RoundCeil
+	// RoundHalfEven rounds to the nearest value, with ties to even.
+	RoundHalfEven
+	// RoundHalfAwayFromZero rounds to the nearest value, with ties away from zero.
+	RoundHalfAwayFromZero
+)
+
+func (mode RoundingMode) String() string {
+	switch mode {
+	case RoundTruncate:
+		return "truncate"
+	case RoundFloor:
+		return "floor"
+	case RoundCeil:
+		return "ceil"
+	case RoundHalfEven:
+		return "half-even"
+	case RoundHalfAwayFromZero:
+		return "half-away-from-zero"
+	default:
+		return "unknown"
+	}
+}
+
+// roundUp reports whether a magnitude that was truncated toward zero
+// must be incremented by one unit to honour the rounding mode.
+// neg is the sign of the result, odd whether the truncated magnitude is odd,
+// inexact whether any non-zero bits were discarded, and half compares the
+// discarded part to half a unit: -1 below, 0 equal, 1 above.
+func (mode RoundingMode) roundUp(neg, odd, inexact bool, half int) bool {
+	if !inexact {
+		return false
+	}
+
+	switch mode {
+	case RoundFloor:
+		return neg
+	case RoundCeil:
+		return !neg
+	case RoundHalfEven:
+		return half > 0 || (half == 0 && odd)
+	case RoundHalfAwayFromZero:
+		return half >= 0
+	default:
+		return false
+	}
+}
+
+// halfCmp compares the fraction r/d with one half, where r < d.
+// A d of zero stands for 2^64, so halfCmp(r, 0) compares r/2^64.
+func halfCmp(r, d uint64) int {
+	other := d - r
+	switch {
+	case r < other:
+		return -1
+	case r > other:
+		return 1
+	default:
+		return 0
+	}
+}
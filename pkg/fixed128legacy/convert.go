@@ -0,0 +1,65 @@
+package fixed128legacy
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+var (
+	ErrorNaN      = fmt.Errorf("not a number")
+	ErrorInfinity = fmt.Errorf("infinite value")
+)
+
+func FromFloat64(x float64) (Fixed128, error) {
+	switch {
+	case math.IsNaN(x):
+		return Fixed128{}, ErrorNaN
+	case math.IsInf(x, 0):
+		return Fixed128{}, ErrorInfinity
+	}
+	r, _ := new(big.Float).SetFloat64(x).Rat(nil)
+	return FromBigRat(r)
+}
+
+func (f128 Fixed128) Float64() float64 {
+	x, _ := f128.BigFloat().Float64()
+	return x
+}
+
+func FromBigInt(x *big.Int) (Fixed128, error) {
+	return FromScaledBigInt(new(big.Int).Mul(x, one))
+}
+
+func FromScaledBigInt(x *big.Int) (Fixed128, error) {
+	var f128 Fixed128
+	f128.value.Set(x)
+	return checked(f128, ErrorOutOfRange)
+}
+
+func (f128 Fixed128) ScaledBigInt() *big.Int {
+	return new(big.Int).Set(&f128.value)
+}
+
+func FromBigRat(r *big.Rat) (Fixed128, error) {
+	num := new(big.Int).Mul(r.Num(), one)
+	return FromScaledBigInt(roundQuo(num, r.Denom(), RoundHalfEven))
+}
+
+func (f128 Fixed128) BigRat() *big.Rat {
+	return new(big.Rat).SetFrac(&f128.value, one)
+}
+
+func FromBigFloat(x *big.Float) (Fixed128, error) {
+	if x.IsInf() {
+		return Fixed128{}, ErrorInfinity
+	}
+	r, _ := x.Rat(nil)
+	return FromBigRat(r)
+}
+
+// BigFloat returns the exact value with 128 bits of precision.
+func (f128 Fixed128) BigFloat() *big.Float {
+	x := new(big.Float).SetPrec(128).SetInt(&f128.value)
+	return x.SetMantExp(x, -64)
+}
@@ -1,55 +1,160 @@
 package fixed128legacy
 
 import (
+	"encoding/binary"
 	"fmt"
 	"math/big"
 )
 
+// The errors mirror those of fixed128 message for message, so that the
+// two packages can be compared operation by operation.
 var (
-	ErrorDivisionByZero = fmt.Errorf("division by zero")
+	ErrorDivisionByZero         = fmt.Errorf("division by zero")
+	ErrorAdditionOverflow       = fmt.Errorf("addition overflow")
+	ErrorSubtractionUnderflow   = fmt.Errorf("subtraction underflow")
+	ErrorMultiplicationOverflow = fmt.Errorf("multiplication overflow")
+	ErrorDivisionOverflow       = fmt.Errorf("division overflow")
+	ErrorBadByteLength          = fmt.Errorf("bad byte length")
+	ErrorUnknownVersion         = fmt.Errorf("unknown encoding version")
+	ErrorShiftOverflow          = fmt.Errorf("shift overflow")
+	ErrorNegativeSqrt           = fmt.Errorf("square root of negative number")
 )
 
+// RoundingMode mirrors fixed128.RoundingMode, with the same values.
+type RoundingMode int
+
+const (
+	RoundTruncate RoundingMode = iota
+	RoundFloor
+	RoundCeil
+	RoundHalfEven
+	RoundHalfAwayFromZero
+)
+
+// one is the fixed-point scale, 2^64
+var one = new(big.Int).Lsh(big.NewInt(1), 64)
+
+// mask keeps the low 128 bits of a magnitude
+var mask = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+
+var (
+	Max = FromParts(^uint64(0), ^uint64(0), false)
+	Min = FromParts(^uint64(0), ^uint64(0), true)
+)
+
+// Fixed128 is a reference implementation of fixed128.Fixed128 backed by
+// a big.Int holding the value multiplied by 2^64. Every operation computes
+// the exact result and only then checks that it fits in 128 bits.
 type Fixed128 struct {
 	value big.Int
 }
 
 func New(x, y int64) (Fixed128, error) {
+	return ByDivisionRounded(x, y, RoundTruncate)
+}
+
+func MustNew(x, y int64) Fixed128 {
+	f128, err := New(x, y)
+	if err != nil {
+		panic(err)
+	}
+	return f128
+}
+
+// ByDivision is New under the name used by fixed128.
+func ByDivision(x, y int64) (Fixed128, error) {
+	return New(x, y)
+}
+
+func MustByDivision(x, y int64) Fixed128 {
+	return MustNew(x, y)
+}
+
+func ByDivisionRounded(x, y int64, mode RoundingMode) (Fixed128, error) {
 	var f128 Fixed128
 
 	if y == 0 {
 		return f128, ErrorDivisionByZero
 	}
 
-	absX, negX := normalize(x)
-	absY, negY := normalize(y)
-	neg := negX != negY
-
-	xBig := big.NewInt(0).SetUint64(absX)
-	yBig := big.NewInt(0).SetUint64(absY)
-
-	f128.value.Lsh(xBig, 64)
-	f128.value.Div(&f128.value, yBig)
+	num := new(big.Int).Lsh(big.NewInt(x), 64)
+	f128.value.Set(roundQuo(num, big.NewInt(y), mode))
+	return f128, nil
+}
 
+// FromParts creates a Fixed128 from a 64-bit whole part,
+// a 64-bit fractional part and a sign.
+func FromParts(hi, lo uint64, neg bool) Fixed128 {
+	var f128 Fixed128
+	f128.value.SetUint64(hi)
+	f128.value.Lsh(&f128.value, 64)
+	f128.value.Or(&f128.value, new(big.Int).SetUint64(lo))
 	if neg {
 		f128.value.Neg(&f128.value)
 	}
+	return f128
+}
 
-	return f128, nil
+// roundQuo returns x / y rounded to an integer according to mode.
+func roundQuo(x, y *big.Int, mode RoundingMode) *big.Int {
+	q, r := new(big.Int).QuoRem(x, y, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+
+	neg := x.Sign() != y.Sign()
+	half := new(big.Int).Lsh(r, 1)
+	cmp := half.Abs(half).CmpAbs(y)
+
+	var up bool
+	switch mode {
+	case RoundFloor:
+		up = neg
+	case RoundCeil:
+		up = !neg
+	case RoundHalfEven:
+		up = cmp > 0 || (cmp == 0 && q.Bit(0) == 1)
+	case RoundHalfAwayFromZero:
+		up = cmp >= 0
+	}
+	if up {
+		if neg {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return q
 }
 
-func MustNew(x, y int64) Fixed128 {
-	f128, err := New(x, y)
-	if err != nil {
-		panic(err)
+// checked returns result, or err if it does not fit in 128 bits.
+func checked(result Fixed128, err error) (Fixed128, error) {
+	if result.value.BitLen() > 128 {
+		return Fixed128{}, err
 	}
-	return f128
+	return result, nil
 }
 
-func normalize(v int64) (uint64, bool) {
-	mask := uint64(v >> 63)
-	neg := mask != 0
-	abs := (uint64(v) ^ mask) - mask
-	return abs, neg
+// saturated clamps result to Max or Min.
+func saturated(result Fixed128) Fixed128 {
+	if result.value.BitLen() <= 128 {
+		return result
+	}
+	if result.IsNeg() {
+		return Min.Copy()
+	}
+	return Max.Copy()
+}
+
+// wrapped reduces the magnitude of result modulo 2^128, keeping its sign.
+func wrapped(result Fixed128) Fixed128 {
+	neg := result.IsNeg()
+	result.value.Abs(&result.value)
+	result.value.And(&result.value, mask)
+	if neg {
+		result.value.Neg(&result.value)
+	}
+	return result
 }
 
 func (f128 Fixed128) Copy() Fixed128 {
@@ -78,45 +183,248 @@ func (f128 Fixed128) IsZero() bool {
 	return f128.value.Sign() == 0
 }
 
-// func (f128 Fixed128) HiLo() (uint64, uint64) {
-// 	_, hi, lo := disassemble(f128)
-// 	return hi, lo
-// }
+// Parts returns the whole part, fractional part and sign of the value.
+// Magnitudes of 2^128 or more are truncated to their low 128 bits.
+func (f128 Fixed128) Parts() (uint64, uint64, bool) {
+	var abs, mask big.Int
+	abs.Abs(&f128.value)
+	mask.Sub(one, big.NewInt(1))
+
+	var hi, lo big.Int
+	lo.And(&abs, &mask)
+	hi.Rsh(&abs, 64)
+	hi.And(&hi, &mask)
 
-// func (f128 Fixed128) Bytes() []byte {
-// 	return f128.bytes()
-// }
+	return hi.Uint64(), lo.Uint64(), f128.IsNeg()
+}
 
-func (f128 Fixed128) Add(b Fixed128) Fixed128 {
+// Bytes returns the legacy 16-byte layout of the magnitude.
+func (f128 Fixed128) Bytes() []byte {
+	hi, lo, _ := f128.Parts()
+	b := make([]byte, 16)
+	binary.BigEndian.PutUint64(b[:8], hi)
+	binary.BigEndian.PutUint64(b[8:], lo)
+	return b
+}
+
+// FromBytes decodes the legacy 16-byte layout or the signed
+// 17-byte version 1 layout.
+func FromBytes(b []byte) (Fixed128, error) {
+	var neg bool
+	switch len(b) {
+	case 16:
+	case 17:
+		if b[0]&0x7f != 0x01 {
+			return Fixed128{}, ErrorUnknownVersion
+		}
+		neg = b[0]&0x80 != 0
+		b = b[1:]
+	default:
+		return Fixed128{}, ErrorBadByteLength
+	}
+	return FromParts(binary.BigEndian.Uint64(b[:8]), binary.BigEndian.Uint64(b[8:]), neg), nil
+}
+
+// AppendBinary appends the signed version 1 layout to b.
+func (f128 Fixed128) AppendBinary(b []byte) ([]byte, error) {
+	tag := byte(0x01)
+	if f128.IsNeg() {
+		tag |= 0x80
+	}
+	return append(append(b, tag), f128.Bytes()...), nil
+}
+
+func (f128 Fixed128) MarshalBinary() ([]byte, error) {
+	return f128.AppendBinary(nil)
+}
+
+func (f128 *Fixed128) UnmarshalBinary(data []byte) error {
+	value, err := FromBytes(data)
+	if err != nil {
+		return err
+	}
+	*f128 = value
+	return nil
+}
+
+func (f128 Fixed128) Negate() Fixed128 {
 	var result Fixed128
-	result.value.Add(&f128.value, &b.value)
+	result.value.Neg(&f128.value)
 	return result
 }
 
-func (f128 Fixed128) Sub(b Fixed128) Fixed128 {
+func (f128 Fixed128) Add(b Fixed128) (Fixed128, error) {
+	return checked(f128.exactAdd(b), ErrorAdditionOverflow)
+}
+
+func (f128 Fixed128) Sub(b Fixed128) (Fixed128, error) {
+	return checked(f128.exactAdd(b.Negate()), ErrorAdditionOverflow)
+}
+
+func (f128 Fixed128) AddSaturating(b Fixed128) Fixed128 {
+	return saturated(f128.exactAdd(b))
+}
+
+func (f128 Fixed128) SubSaturating(b Fixed128) Fixed128 {
+	return saturated(f128.exactAdd(b.Negate()))
+}
+
+func (f128 Fixed128) AddWrapping(b Fixed128) Fixed128 {
+	return wrapped(f128.exactAdd(b))
+}
+
+func (f128 Fixed128) SubWrapping(b Fixed128) Fixed128 {
+	return wrapped(f128.exactAdd(b.Negate()))
+}
+
+func (f128 Fixed128) exactAdd(b Fixed128) Fixed128 {
 	var result Fixed128
-	result.value.Sub(&f128.value, &b.value)
+	result.value.Add(&f128.value, &b.value)
 	return result
 }
 
-func (f128 Fixed128) Mul(b Fixed128) Fixed128 {
+// Mul multiplies two fixed-point values, truncating toward zero.
+func (f128 Fixed128) Mul(b Fixed128) (Fixed128, error) {
+	return checked(f128.exactMul(b), ErrorMultiplicationOverflow)
+}
+
+func (f128 Fixed128) MulSaturating(b Fixed128) Fixed128 {
+	return saturated(f128.exactMul(b))
+}
+
+func (f128 Fixed128) MulWrapping(b Fixed128) Fixed128 {
+	return wrapped(f128.exactMul(b))
+}
+
+func (f128 Fixed128) exactMul(b Fixed128) Fixed128 {
 	var result Fixed128
 	result.value.Mul(&f128.value, &b.value)
+	result.value.Quo(&result.value, one)
 	return result
 }
 
+// Quo divides two fixed-point values, truncating toward zero.
 func (f128 Fixed128) Quo(b Fixed128) (Fixed128, error) {
 	var result Fixed128
-	result.value.Quo(&f128.value, &b.value)
+	if b.IsZero() {
+		return result, ErrorDivisionByZero
+	}
+	result.value.Lsh(&f128.value, 64)
+	result.value.Quo(&result.value, &b.value)
+	return checked(result, ErrorDivisionOverflow)
+}
+
+// Rem returns the remainder of the truncated division of two
+// fixed-point values. The result has the sign of f128.
+func (f128 Fixed128) Rem(b Fixed128) (Fixed128, error) {
+	var result Fixed128
+	if b.IsZero() {
+		return result, ErrorDivisionByZero
+	}
+	result.value.Rem(&f128.value, &b.value)
 	return result, nil
 }
 
-func (f128 Fixed128) Lsh(bits uint) Fixed128 {
+func (f128 Fixed128) MulInt64(y int64) (int64, error) {
+	return f128.MulInt64Rounded(y, RoundTruncate)
+}
+
+func (f128 Fixed128) MulInt64Rounded(y int64, mode RoundingMode) (int64, error) {
+	product := new(big.Int).Mul(&f128.value, big.NewInt(y))
+	result := roundQuo(product, one, mode)
+	if !result.IsInt64() {
+		return 0, ErrorAdditionOverflow
+	}
+	return result.Int64(), nil
+}
+
+func (f128 Fixed128) Lsh(bits uint) (Fixed128, error) {
+	if f128.IsZero() {
+		return Fixed128{}, nil
+	}
+	if uint(f128.value.BitLen())+bits > 128 {
+		return Fixed128{}, ErrorShiftOverflow
+	}
 	var result Fixed128
 	result.value.Lsh(&f128.value, bits)
+	return result, nil
+}
+
+// Rsh divides by 2^bits, rounding toward negative infinity.
+func (f128 Fixed128) Rsh(bits uint) Fixed128 {
+	var result Fixed128
+	result.value.Rsh(&f128.value, bits)
 	return result
 }
 
-// func (f128 Fixed128) MulInt64(y int64) (int64, error) {
-// 	return mulInt64(f128, y)
-// }
+func (f128 Fixed128) Abs() Fixed128 {
+	var result Fixed128
+	result.value.Abs(&f128.value)
+	return result
+}
+
+func (f128 Fixed128) Min(b Fixed128) Fixed128 {
+	if b.Cmp(f128) < 0 {
+		return b.Copy()
+	}
+	return f128.Copy()
+}
+
+func (f128 Fixed128) Max(b Fixed128) Fixed128 {
+	if b.Cmp(f128) > 0 {
+		return b.Copy()
+	}
+	return f128.Copy()
+}
+
+// Round rounds to fracBits fractional bits according to mode.
+func (f128 Fixed128) Round(fracBits uint, mode RoundingMode) (Fixed128, error) {
+	if fracBits >= 64 {
+		return f128.Copy(), nil
+	}
+
+	unit := new(big.Int).Lsh(big.NewInt(1), 64-fracBits)
+
+	var result Fixed128
+	result.value.Mul(roundQuo(&f128.value, unit, mode), unit)
+	return checked(result, ErrorAdditionOverflow)
+}
+
+func (f128 Fixed128) Floor() (Fixed128, error) {
+	return f128.Round(0, RoundFloor)
+}
+
+func (f128 Fixed128) Ceil() (Fixed128, error) {
+	return f128.Round(0, RoundCeil)
+}
+
+func (f128 Fixed128) Trunc() Fixed128 {
+	result, _ := f128.Round(0, RoundTruncate)
+	return result
+}
+
+// Frac returns the fractional part with the sign of f128.
+func (f128 Fixed128) Frac() Fixed128 {
+	var result Fixed128
+	result.value.Rem(&f128.value, one)
+	return result
+}
+
+// Sqrt returns the square root rounded to nearest.
+func (f128 Fixed128) Sqrt() (Fixed128, error) {
+	var result Fixed128
+	if f128.IsNeg() {
+		return result, ErrorNegativeSqrt
+	}
+
+	n := new(big.Int).Lsh(&f128.value, 64)
+	result.value.Sqrt(n)
+
+	// Round up when n - r^2 > r
+	rem := new(big.Int).Mul(&result.value, &result.value)
+	rem.Sub(n, rem)
+	if rem.Cmp(&result.value) > 0 {
+		result.value.Add(&result.value, big.NewInt(1))
+	}
+	return result, nil
+}
@@ -0,0 +1,191 @@
+package fixed128legacy
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+var (
+	ErrorInvalidFormat = fmt.Errorf("invalid format")
+	ErrorOutOfRange    = fmt.Errorf("value out of range")
+)
+
+var _ fmt.Formatter = Fixed128{}
+
+func (f128 Fixed128) String() string {
+	return f128.hex(false)
+}
+
+// Decimal returns the decimal form with prec digits after the decimal
+// point, rounded half to even, or the exact expansion if prec is negative.
+func (f128 Fixed128) Decimal(prec int) string {
+	if prec < 0 {
+		// 2^-64 has exactly 64 decimal places, so nothing is lost
+		s := f128.Abs().decimal(64)
+		s = strings.TrimRight(s, "0")
+		s = strings.TrimSuffix(s, ".")
+		return f128.sign() + s
+	}
+	return f128.sign() + f128.Abs().decimal(prec)
+}
+
+func (f128 Fixed128) Base64() string {
+	return base64.StdEncoding.EncodeToString(f128.Bytes())
+}
+
+func (f128 Fixed128) Base64URL() string {
+	return base64.URLEncoding.EncodeToString(f128.Bytes())
+}
+
+// Format implements fmt.Formatter with the same verbs and flags as
+// fixed128.Fixed128.
+func (f128 Fixed128) Format(s fmt.State, verb rune) {
+	var out string
+	switch verb {
+	case 'v', 's', 'x':
+		out = f128.hex(false)
+	case 'X':
+		out = f128.hex(true)
+	case 'd':
+		out = new(big.Int).Quo(&f128.value, one).String()
+	case 'f':
+		prec, ok := s.Precision()
+		if !ok {
+			prec = -1
+		}
+		out = f128.Decimal(prec)
+	default:
+		fmt.Fprintf(s, "%%!%c(fixed128legacy.Fixed128=%s)", verb, f128.String())
+		return
+	}
+
+	if s.Flag('+') && !strings.HasPrefix(out, "-") {
+		out = "+" + out
+	}
+
+	width, _ := s.Width()
+	pad := width - len(out)
+	switch {
+	case pad <= 0:
+	case s.Flag('-'):
+		out += strings.Repeat(" ", pad)
+	case s.Flag('0'):
+		sign := 0
+		if strings.HasPrefix(out, "-") || strings.HasPrefix(out, "+") {
+			sign = 1
+		}
+		out = out[:sign] + strings.Repeat("0", pad) + out[sign:]
+	default:
+		out = strings.Repeat(" ", pad) + out
+	}
+	fmt.Fprint(s, out)
+}
+
+func ParseHex(s string) (Fixed128, error) {
+	neg, rest := cutSign(s)
+	whole, frac, _ := strings.Cut(rest, ".")
+	if len(whole) == 0 || len(whole) > 16 || len(frac) > 16 ||
+		!isDigits(whole, 16) || !isDigits(frac, 16) {
+		return Fixed128{}, fmt.Errorf("%w: %q", ErrorInvalidFormat, s)
+	}
+
+	// Padding the fraction to 16 digits makes the whole string the
+	// hexadecimal form of the scaled value
+	var f128 Fixed128
+	f128.value.SetString(whole+frac+strings.Repeat("0", 16-len(frac)), 16)
+	if neg {
+		f128.value.Neg(&f128.value)
+	}
+	return f128, nil
+}
+
+func ParseDecimal(s string) (Fixed128, error) {
+	neg, rest := cutSign(s)
+	whole, frac, _ := strings.Cut(rest, ".")
+	if whole == "" || !isDigits(whole, 10) || !isDigits(frac, 10) {
+		return Fixed128{}, fmt.Errorf("%w: %q", ErrorInvalidFormat, s)
+	}
+
+	num, _ := new(big.Int).SetString(whole+frac, 10)
+	den := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(len(frac))), nil)
+	if new(big.Int).Quo(num, den).BitLen() > 64 {
+		return Fixed128{}, fmt.Errorf("%w: %q", ErrorOutOfRange, s)
+	}
+
+	var f128 Fixed128
+	f128.value.Set(roundQuo(num.Lsh(num, 64), den, RoundHalfEven))
+	if f128.value.BitLen() > 128 {
+		return Fixed128{}, fmt.Errorf("%w: %q", ErrorOutOfRange, s)
+	}
+	if neg {
+		f128.value.Neg(&f128.value)
+	}
+	return f128, nil
+}
+
+func ParseBase64(s string) (Fixed128, error) {
+	return parseBase64(base64.StdEncoding, s)
+}
+
+func ParseBase64URL(s string) (Fixed128, error) {
+	return parseBase64(base64.URLEncoding, s)
+}
+
+func parseBase64(enc *base64.Encoding, s string) (Fixed128, error) {
+	b, err := enc.DecodeString(s)
+	if err != nil {
+		return Fixed128{}, fmt.Errorf("%w: %q", ErrorInvalidFormat, s)
+	}
+	return FromBytes(b)
+}
+
+func (f128 Fixed128) hex(upper bool) string {
+	hi, lo, _ := f128.Parts()
+	format := "%s%016x.%016x"
+	if upper {
+		format = "%s%016X.%016X"
+	}
+	return fmt.Sprintf(format, f128.sign(), hi, lo)
+}
+
+// decimal formats a non-negative value with exactly prec fractional digits.
+func (f128 Fixed128) decimal(prec int) string {
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(prec)), nil)
+	digits := roundQuo(new(big.Int).Mul(&f128.value, scale), one, RoundHalfEven).String()
+	if prec == 0 {
+		return digits
+	}
+	if len(digits) <= prec {
+		digits = strings.Repeat("0", prec-len(digits)+1) + digits
+	}
+	return digits[:len(digits)-prec] + "." + digits[len(digits)-prec:]
+}
+
+func (f128 Fixed128) sign() string {
+	if f128.IsNeg() {
+		return "-"
+	}
+	return ""
+}
+
+func cutSign(s string) (bool, string) {
+	if rest, ok := strings.CutPrefix(s, "-"); ok {
+		return true, rest
+	}
+	rest, _ := strings.CutPrefix(s, "+")
+	return false, rest
+}
+
+func isDigits(s string, base int) bool {
+	for _, c := range s {
+		switch {
+		case c >= '0' && c <= '9':
+		case base == 16 && (c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'):
+		default:
+			return false
+		}
+	}
+	return true
+}
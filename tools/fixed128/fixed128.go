@@ -7,7 +7,7 @@ import (
 	"strconv"
 
 	"github.com/seannyphoenix/binarytime/pkg/binarytime"
-	"github.com/seannyphoenix/binarytime/pkg/uint128"
+	"github.com/seannyphoenix/binarytime/pkg/fixed128"
 )
 
 func main() {
@@ -25,8 +25,8 @@ var funcs = map[string]func(){
 }
 
 func d() {
-	f128 := uint128.MustNew(123478392543, 134332)
-	// f128 := fixed128.MustNew(-30, 12)
+	f128 := fixed128.MustByDivision(123478392543, 134332)
+	// f128 := fixed128.MustByDivision(-30, 12)
 	b, err := json.MarshalIndent(f128, "", "  ")
 	if err != nil {
 		fmt.Println("Error marshaling JSON:", err)
@@ -39,7 +39,7 @@ func d() {
 }
 
 func c() {
-	v1 := uint128.MustNew(30, 6)
+	v1 := fixed128.MustByDivision(30, 6)
 	fmt.Println("Fixed128 value:", v1)
 }
 
@@ -47,7 +47,7 @@ func b() {
 	nbt := binarytime.Now()
 	fmt.Println("Current binary time:", nbt)
 	fmt.Println("Current binary time:", nbt.Fixed128())
-	fmt.Println("Current binary time:", nbt.Fixed128().Value())
+	fmt.Printf("Current binary time: %f\n", nbt.Fixed128())
 }
 
 func a() {
@@ -58,7 +58,7 @@ func a() {
 	}
 
 	fmt.Println("Arguments received:", x, y)
-	f128, err := uint128.New(x, y)
+	f128, err := fixed128.ByDivision(x, y)
 	if err != nil {
 		fmt.Println("Error creating Fixed128:", err)
 		return
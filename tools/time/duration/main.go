@@ -16,5 +16,7 @@ var funcs = map[string]func(){
 }
 
 func a() {
-	fmt.Println(binarytime.FromDuration(8 * time.Hour).String())
+	d := binarytime.FromDuration(8 * time.Hour)
+	fmt.Println(d.String())
+	fmt.Println(d.Humanize())
 }